@@ -2,9 +2,13 @@ package checking
 
 import (
 	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"gomc/event"
 	"gomc/state"
+	"reflect"
+	"runtime"
 	"text/tabwriter"
 )
 
@@ -54,6 +58,20 @@ func (pcr predicateCheckerResponse[S]) Export() []event.EventId {
 	return evtSequence
 }
 
+// Export the records of the events in the failing event sequence
+//
+// The records contain both the id and a description of the events.
+func (pcr predicateCheckerResponse[S]) ExportRecords() []state.EventRecord {
+	records := []state.EventRecord{}
+	for _, state := range pcr.Sequence {
+		if state.Evt.Id == "" {
+			continue
+		}
+		records = append(records, state.Evt)
+	}
+	return records
+}
+
 // A function defining a property to be verified.
 //
 // Takes a checking.State as input.
@@ -75,6 +93,28 @@ func NewPredicateChecker[S any](predicates ...Predicate[S]) *PredicateChecker[S]
 	}
 }
 
+// Create a hash identifying the set of predicates.
+//
+// The hash is created from the number of predicates and the names of the functions defining them.
+// The hash is stable across builds as long as the predicates are not renamed, added, removed or reordered.
+//
+// The hash is a best-effort check and does not identify the properties that are checked.
+// It does not change if the body of a predicate is changed.
+// Predicates created by the same factory, e.g. checking.Eventually, or by closures in the same function have the same name,
+// so different properties created in the same way result in the same hash.
+func HashPredicates[S any](predicates ...Predicate[S]) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%v", len(predicates))
+	for _, pred := range predicates {
+		name := ""
+		if fn := runtime.FuncForPC(reflect.ValueOf(pred).Pointer()); fn != nil {
+			name = fn.Name()
+		}
+		fmt.Fprintf(h, ";%v", name)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
 // Checks that all predicates holds for all states in the state space.
 //
 // States are searched depth first and the search is interrupted if some state that breaks the predicates are provided
@@ -126,6 +166,7 @@ func (pc *PredicateChecker[S]) checkState(state state.GlobalState[S], terminalSt
 		if !pred(State[S]{
 			LocalStates: state.LocalStates,
 			Correct:     state.Correct,
+			Partition:   state.Partition,
 			IsTerminal:  terminalState,
 			Sequence:    sequence,
 		}) {
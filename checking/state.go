@@ -8,8 +8,19 @@ type State[S any] struct {
 	LocalStates map[int]S
 	// The status of the nodes. True means that the node is correct, false that it has crashed.
 	Correct map[int]bool
+	// The group of each node in the active partition of the network. Nodes can only communicate with nodes in the same group.
+	// Is nil if the network is not partitioned.
+	Partition map[int]int
 	// True if this is the last recorded state in a run. False otherwise.
 	IsTerminal bool
 	// The sequence of GlobalStates that lead to this State.
 	Sequence []state.GlobalState[S]
 }
+
+// Returns true if the nodes can communicate, i.e. they are in the same group of the active partition or the network is not partitioned.
+func (s State[S]) Connected(a, b int) bool {
+	if s.Partition == nil {
+		return true
+	}
+	return s.Partition[a] == s.Partition[b]
+}
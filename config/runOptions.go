@@ -14,6 +14,8 @@ import (
 // Default value is no node crashes.
 type FailureManagerOption[T any] struct {
 	Fm failureManager.FailureManger[T]
+	// The ids of the nodes that the Failure Manager can crash, or the Byzantine nodes. Used to describe the configuration in replay artifacts
+	FailingNodes []int
 }
 
 func (fmo FailureManagerOption[T]) RunOpt() {}
@@ -39,3 +41,14 @@ type StopOption[T any] struct {
 func (so StopOption[T]) RunOpt() {}
 
 func (so StopOption[T]) RunnerOpt() {}
+
+// Configures the network to lose and duplicate messages.
+
+// MaxLosses and MaxDuplicates bound the number of lost and duplicated messages in each run.
+// Default value is a reliable network.
+type NetworkFaultsOption struct {
+	MaxLosses     int
+	MaxDuplicates int
+}
+
+func (nfo NetworkFaultsOption) RunOpt() {}
@@ -7,12 +7,16 @@ import "gomc/scheduler"
 // The scheduler determines the method which will be used to explore the state space.
 // It determines the order that events will be executed in.
 // Default value is a Prefix Scheduler
+//
+// Can also be provided when running a simulation to use a different scheduler for that simulation only.
 type SchedulerOption struct {
 	Sch scheduler.GlobalScheduler
 }
 
 func (so SchedulerOption) SimOpt() {}
 
+func (so SchedulerOption) RunOpt() {}
+
 // Configures the max depth of a run
 
 // The depth of a run is the number of events that are executed in a run.
@@ -0,0 +1,40 @@
+package config
+
+// Configures the node sets that will be swept.
+
+// Each node set is a slice of the ids of the nodes used in a scenario.
+// Can be applied multiple times to add more node sets.
+type SweepNodesOption struct {
+	Nodes [][]int
+}
+
+func (sno SweepNodesOption) SweepOpt() {}
+
+// Configures the combinations of failing nodes that will be swept.
+
+// For each node set all combinations of at most MaxCrashed failing nodes are simulated.
+// CrashFunc is used to perform the crash on the node.
+// Default value is no failing nodes.
+type SweepCrashOption[T any] struct {
+	CrashFunc  func(*T)
+	MaxCrashed int
+}
+
+func (sco SweepCrashOption[T]) SweepOpt() {}
+
+// Configures the sweep to simulate all permutations of the requests.
+
+// Default value is false, i.e. only the order that the requests are generated in is simulated.
+type SweepRequestPermutationOption struct{}
+
+func (srpo SweepRequestPermutationOption) SweepOpt() {}
+
+// Configures the number of scenarios that are simulated concurrently.
+
+// Each concurrent scenario uses a separate Simulation.
+// Default value is GOMAXPROCS
+type SweepConcurrencyOption struct {
+	N int
+}
+
+func (sco SweepConcurrencyOption) SweepOpt() {}
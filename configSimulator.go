@@ -1,6 +1,7 @@
 package gomc
 
 import (
+	"fmt"
 	"io"
 	"log"
 	"runtime"
@@ -10,6 +11,7 @@ import (
 	"gomc/event"
 	"gomc/eventManager"
 	"gomc/failureManager"
+	"gomc/replay"
 	"gomc/request"
 	"gomc/scheduler"
 	"gomc/simulator"
@@ -89,6 +91,7 @@ type Simulation[T, S any] struct {
 // All RunOptions are optional. Default values will be used if no values are provided.
 //
 // Returns a checking.CheckerResponse type containing the results of the simulation
+// Panics with an error wrapping the error of the simulation if the simulation fails, e.g. with a scheduler.DivergenceError if a replayed run diverges.
 func (sr Simulation[T, S]) Run(InitNodes InitNodeOption[T], requestOpts RequestOption, checker CheckerOption[S], opts ...RunOptions) checking.CheckerResponse {
 	// If incorrectNodes is not provided use an empty slice
 	var (
@@ -99,16 +102,31 @@ func (sr Simulation[T, S]) Run(InitNodes InitNodeOption[T], requestOpts RequestO
 		stopFunc = func(*T) {}
 
 		fm failureManager.FailureManger[T]
+
+		sim = sr.sim
 	)
 
 	for _, opt := range opts {
 		switch t := opt.(type) {
+		case config.SchedulerOption:
+			// Use a copy of the simulator so that the scheduler is only replaced for this simulation
+			s := *sr.sim
+			s.Scheduler = t.Sch
+			sim = &s
 		case config.StopOption[T]:
 			stopFunc = t.Stop
 		case config.ExportOption:
 			export = append(export, t.W)
 		case config.FailureManagerOption[T]:
 			fm = t.Fm
+		case config.NetworkFaultsOption:
+			// Use a copy of the simulator so that the network is only unreliable for this simulation
+			s := *sim
+			maxLosses, maxDuplicates := t.MaxLosses, t.MaxDuplicates
+			s.NetworkFaults = func() *eventManager.NetworkFaults {
+				return eventManager.NewNetworkFaults(maxLosses, maxDuplicates)
+			}
+			sim = &s
 		}
 	}
 
@@ -121,9 +139,11 @@ func (sr Simulation[T, S]) Run(InitNodes InitNodeOption[T], requestOpts RequestO
 		log.Panicf("At least one request must be provided to start the simulation")
 	}
 
-	err := sr.sim.Simulate(fm, InitNodes.f, stopFunc, requests...)
+	err := sim.Simulate(fm, InitNodes.f, stopFunc, requests...)
 	if err != nil {
-		log.Panicf("Received an error while running simulation: %v", err)
+		err = fmt.Errorf("Received an error while running simulation: %w", err)
+		log.Print(err)
+		panic(err)
 	}
 
 	state := sr.sm.State()
@@ -167,6 +187,14 @@ func ReplayScheduler(run []event.EventId) SimulatorOption {
 	return config.SchedulerOption{Sch: scheduler.NewReplay(run)}
 }
 
+// Use a bytes scheduler for the simulation
+//
+// The bytes scheduler performs a single run where each choice of the next event is decided by a byte of the provided input.
+// It is used to let a fuzzer steer the exploration of the state space.
+func BytesScheduler(data []byte) SimulatorOption {
+	return config.SchedulerOption{Sch: scheduler.NewBytes(data)}
+}
+
 // Use the provided scheduler for the simulation
 //
 // Used to configure the simulation to use a different implementation of scheduler than is commonly provided
@@ -219,6 +247,72 @@ type RunOptions interface {
 	RunOpt()
 }
 
+// Replay the provided run instead of using the configured scheduler.
+//
+// Only affects the simulation that the option is provided to.
+// The provided run is represented as a slice of event ids, and can be exported using the CheckerResponse.Export()
+func ReplayRun(run []event.EventId) RunOptions {
+	return config.SchedulerOption{Sch: scheduler.NewReplay(run)}
+}
+
+// Replay the run stored in the replay artifact instead of using the configured scheduler.
+//
+// Only affects the simulation that the option is provided to.
+// The artifact should be loaded using replay.Load, which validates that the artifact was recorded using the same configuration.
+// If the run diverges from the recorded run the error describes the event that could not be replayed.
+func ReplayArtifact(a replay.Artifact) RunOptions {
+	return config.SchedulerOption{Sch: scheduler.NewReplayWithDescriptions(a.Run, a.Events)}
+}
+
+// Replay the provided run in best effort mode instead of using the configured scheduler.
+//
+// Only affects the simulation that the option is provided to.
+// When the next event in the run is not pending the simulation continues with the pending event that most closely matches it, instead of returning an error.
+// onDivergence is called with each divergence when it is encountered, e.g. to report where the run diverged. May be nil.
+func BestEffortReplayRun(run []event.EventId, onDivergence func(scheduler.DivergenceError)) RunOptions {
+	return config.SchedulerOption{Sch: scheduler.NewBestEffortReplay(run, nil, onDivergence)}
+}
+
+// Replay the run stored in the replay artifact in best effort mode instead of using the configured scheduler.
+//
+// Only affects the simulation that the option is provided to.
+// See BestEffortReplayRun.
+func BestEffortReplayArtifact(a replay.Artifact, onDivergence func(scheduler.DivergenceError)) RunOptions {
+	return config.SchedulerOption{Sch: scheduler.NewBestEffortReplay(a.Run, a.Events, onDivergence)}
+}
+
+// Describe the configuration of a simulation that is recorded in, or validated against, a replay artifact.
+//
+// nodes is the ids of the nodes and failingNodes is the ids of the nodes that crash during the simulation.
+// seed is the seed used by the scheduler. It should be 0 if the scheduler is not randomized.
+// predicates is the predicates that are checked by the simulation.
+func ReplayConfig[S any](nodes []int, failingNodes []int, requests RequestOption, seed int64, predicates ...checking.Predicate[S]) replay.Config {
+	return replay.NewConfig(nodes, failingNodes, requests.request, seed, checking.HashPredicates(predicates...))
+}
+
+// Describe the configuration of a simulation from the options used to run it.
+//
+// The nodes are only recorded if they are created using InitSingleNode, and the properties are only recorded if the checker is created using WithPredicateChecker.
+// The failing nodes are recorded if the failure manager is configured using one of the With...FailureManager options, other than WithFailureManager.
+// The seed is 0, since the scheduler is configured on the Simulation. Use ReplayConfig to describe the complete configuration.
+func ScenarioConfig[T, S any](initNodes InitNodeOption[T], requests RequestOption, checker CheckerOption[S], opts ...RunOptions) replay.Config {
+	var failingNodes []int
+	for _, opt := range opts {
+		if t, ok := opt.(config.FailureManagerOption[T]); ok {
+			failingNodes = t.FailingNodes
+		}
+	}
+	return replay.NewConfig(initNodes.nodes, failingNodes, requests.request, 0, checker.propertiesHash)
+}
+
+// Perform a single run where the choices are decided by the provided input instead of using the configured scheduler.
+//
+// Only affects the simulation that the option is provided to.
+// See BytesScheduler.
+func BytesRun(data []byte) RunOptions {
+	return config.SchedulerOption{Sch: scheduler.NewBytes(data)}
+}
+
 // Specify the failure manager used for the Simulation
 //
 // Default value is a PerfectFailureManager with no node crashes.
@@ -237,7 +331,111 @@ func WithPerfectFailureManager[T any](crashFunc func(*T), failingNodes ...int) R
 		crashFunc,
 		failingNodes,
 	)
-	return config.FailureManagerOption[T]{Fm: fm}
+	return config.FailureManagerOption[T]{Fm: fm, FailingNodes: failingNodes}
+}
+
+// Configure the simulation to use a bounded PerfectFailureManager.
+//
+// The crash of each of the failing nodes is a nondeterministic choice, and at most maxCrashes nodes crash in a run.
+// All combinations of crashes are explored in a single simulation, i.e. any maxCrashes of the failing nodes may crash.
+// If no failing nodes are provided, all nodes may crash.
+func WithBoundedCrashes[T any](crashFunc func(*T), maxCrashes int, failingNodes ...int) RunOptions {
+	fm := failureManager.NewBoundedPerfectFailureManager(
+		crashFunc,
+		maxCrashes,
+		failingNodes,
+	)
+	return config.FailureManagerOption[T]{Fm: fm, FailingNodes: failingNodes}
+}
+
+// Configure the simulation to use an EventuallyPerfectFailureManager.
+//
+// The EventuallyPerfectFailureManager implements crash-stop failures in a partially synchronous system.
+// It imitates the behavior of the Eventually Perfect Failure Detector.
+// Nodes subscribed to crash updates can falsely suspect correct nodes, and will later restore them.
+// maxFalseSuspicions bounds the number of false suspicions in a run.
+func WithEventuallyPerfectFailureManager[T any](crashFunc func(*T), maxFalseSuspicions int, failingNodes ...int) RunOptions {
+	fm := failureManager.NewEventuallyPerfectFailureManager(
+		crashFunc,
+		maxFalseSuspicions,
+		failingNodes,
+	)
+	return config.FailureManagerOption[T]{Fm: fm, FailingNodes: failingNodes}
+}
+
+// Configure the simulation to use an OmegaFailureManager.
+//
+// The OmegaFailureManager implements crash-stop failures and provides the Omega leader oracle.
+// Nodes subscribe to leader changes using the LeaderSubscribe function of the SimulationParameters.
+// Changes of the leader are nondeterministic, and bounded by maxLeaderChanges in a run.
+// A single correct leader is eventually trusted by all correct nodes.
+func WithOmegaFailureManager[T any](crashFunc func(*T), maxLeaderChanges int, failingNodes ...int) RunOptions {
+	fm := failureManager.NewOmegaFailureManager(
+		crashFunc,
+		maxLeaderChanges,
+		failingNodes,
+	)
+	return config.FailureManagerOption[T]{Fm: fm, FailingNodes: failingNodes}
+}
+
+// Configure the simulation to use a PartitionFailureManager.
+//
+// The PartitionFailureManager implements crash-stop failures and partitions of the network.
+// partitions is the possible partitions of the network, where each partition is a slice of groups of node ids.
+// Nodes that are not in any of the groups of a partition form a group together.
+// While a partition is active, messages between nodes in different groups are dropped.
+// maxPartitions bounds the number of partitions in a run.
+func WithPartitionFailureManager[T any](crashFunc func(*T), partitions [][][]int, maxPartitions int, failingNodes ...int) RunOptions {
+	fm := failureManager.NewPartitionFailureManager(
+		crashFunc,
+		partitions,
+		maxPartitions,
+		failingNodes,
+	)
+	return config.FailureManagerOption[T]{Fm: fm, FailingNodes: failingNodes}
+}
+
+// Configure the simulation to use a ByzantineFailureManager.
+//
+// Messages sent by the Byzantine nodes using the Sender can be altered by one of the mutations, omitted, or replaced by a replay of an earlier message from the node.
+// Mutations can depend on the receiver of the message, which allows the Byzantine nodes to equivocate.
+// maxFaults bounds the number of faulty messages in a run.
+// Byzantine nodes are reported as not correct in the GlobalState.
+func WithByzantineFailureManager[T any](mutations []failureManager.Mutation, maxFaults int, byzantineNodes ...int) RunOptions {
+	fm := failureManager.NewByzantineFailureManager[T](
+		byzantineNodes,
+		mutations,
+		maxFaults,
+	)
+	return config.FailureManagerOption[T]{Fm: fm, FailingNodes: byzantineNodes}
+}
+
+// Configure the simulation to use a CrashRecoveryFailureManager.
+//
+// The CrashRecoveryFailureManager implements crash-recovery failures.
+// Each failing node crashes and recovers up to maxCrashes times during a run.
+// persist collects the stable storage of a node when it crashes.
+// recoverFunc rebuilds the node from its stable storage when it recovers. All volatile state is lost.
+func WithCrashRecoveryFailureManager[T, P any](crashFunc func(*T), persist func(*T) P, recoverFunc func(id int, persisted P, sp eventManager.SimulationParameters) *T, maxCrashes int, failingNodes ...int) RunOptions {
+	fm := failureManager.NewCrashRecoveryFailureManager(
+		crashFunc,
+		persist,
+		recoverFunc,
+		maxCrashes,
+		failingNodes,
+	)
+	return config.FailureManagerOption[T]{Fm: fm, FailingNodes: failingNodes}
+}
+
+// Configure the network to lose and duplicate messages.
+//
+// Messages sent using the Sender or the GrpcEventManager can be lost or duplicated as a nondeterministic choice.
+// maxLosses and maxDuplicates bound the number of lost and duplicated messages in each run, to keep the state space finite.
+// Messages are always reordered by the scheduler.
+//
+// Default value is a reliable network, where each message is delivered exactly once.
+func WithNetworkFaults(maxLosses, maxDuplicates int) RunOptions {
+	return config.NetworkFaultsOption{MaxLosses: maxLosses, MaxDuplicates: maxDuplicates}
 }
 
 // Configure the StateManager used to manage the state of the distributed system.
@@ -268,6 +466,8 @@ func WithTreeStateManager[T, S any](getLocalState func(*T) S, statesEqual func(S
 // The provided SimulationParameters should be used to configure the Event Managers with run specific data.
 type InitNodeOption[T any] struct {
 	f func(eventManager.SimulationParameters) map[int]*T
+	// The ids of the nodes if they are known in advance
+	nodes []int
 }
 
 // Uses the provided function f to generate a map of the nodes.
@@ -284,7 +484,7 @@ func InitSingleNode[T any](nodeIds []int, f func(id int, sp eventManager.Simulat
 		}
 		return nodes
 	}
-	return InitNodeOption[T]{f: t}
+	return InitNodeOption[T]{f: t, nodes: nodeIds}
 }
 
 // Configures the Checker to be used when verifying the algorithm.
@@ -293,6 +493,8 @@ func InitSingleNode[T any](nodeIds []int, f func(id int, sp eventManager.Simulat
 // It returns a CheckerResponse with the result of the simulation.
 type CheckerOption[S any] struct {
 	checker checking.Checker[S]
+	// Identifies the predicates of the checker. See checking.HashPredicates
+	propertiesHash string
 }
 
 // Use a PredicateChecker to verify the algorithm.
@@ -301,7 +503,8 @@ type CheckerOption[S any] struct {
 // functions are provided as the checking.Predicate type.
 func WithPredicateChecker[S any](predicates ...checking.Predicate[S]) CheckerOption[S] {
 	return CheckerOption[S]{
-		checker: checking.NewPredicateChecker(predicates...),
+		checker:        checking.NewPredicateChecker(predicates...),
+		propertiesHash: checking.HashPredicates(predicates...),
 	}
 }
 
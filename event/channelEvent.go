@@ -0,0 +1,71 @@
+package event
+
+import (
+	"fmt"
+)
+
+// An Event representing a message sent on a channel arriving at the receiving node.
+//
+// The message is delivered on the channel returned by the Recv method of the endpoint of the receiving node.
+type ChannelEvent struct {
+	from    int
+	target  int
+	msg     string
+	deliver func() bool
+
+	id EventId
+}
+
+// Create a new ChannelEvent
+//
+// from is the id of the node sending the message, to is the id of the node receiving it, and msg is a representation of the message.
+// deliver delivers the message, and returns true if the event is completed.
+// Otherwise the receiving node signals that the event is completed when it has handled the message.
+func NewChannelEvent(from int, to int, msg string, deliver func() bool) ChannelEvent {
+	return ChannelEvent{
+		from:    from,
+		target:  to,
+		msg:     msg,
+		deliver: deliver,
+
+		id: EventId(fmt.Sprint("Channel ", from, "-", to, " ", msg)),
+	}
+}
+
+// An id that identifies the event.
+// Two events that provided the same input state results in the same output state should have the same id
+//
+// New event implementations should include a identifier of the event type to prevent accidental collisions with other implementations
+func (ce ChannelEvent) Id() EventId {
+	return ce.id
+}
+
+// A method executing the event.
+//
+// Deliver the message to the receiving node.
+// Only signal on the error channel if the receiving node is not handling the message.
+// Otherwise the receiving node signals when it has handled the message.
+func (ce ChannelEvent) Execute(node any, errorChan chan error) {
+	if ce.deliver() {
+		errorChan <- nil
+	}
+}
+
+// The id of the target node, i.e. the node whose state will be changed by the event executing.
+func (ce ChannelEvent) Target() int {
+	return ce.target
+}
+
+func (ce ChannelEvent) String() string {
+	return fmt.Sprintf("Channel From: %v To: %v Message: %v", ce.from, ce.target, ce.msg)
+}
+
+// Returns the id of the node receiving the event
+func (ce ChannelEvent) To() int {
+	return ce.target
+}
+
+// Returns the id of the node sending the event
+func (ce ChannelEvent) From() int {
+	return ce.from
+}
@@ -10,6 +10,8 @@ import "fmt"
 type CrashDetection struct {
 	targetId    int
 	crashedNode int
+	// The new status of the crashedNode. False if it crashed, true if it recovered.
+	status bool
 
 	callback func(id int, status bool)
 
@@ -32,7 +34,27 @@ func NewCrashDetection(targetNode int, crashedNode int, callback func(int, bool)
 	}
 }
 
+// Event representing the detection of a recovered node.
+//
+// The event is created when a crashed node recovers.
+// When the event is executed the target node will detect that the recoveredNode has recovered.
+// This is done by calling the callback function with status true.
+func NewRecoveryDetection(targetNode int, recoveredNode int, callback func(int, bool)) CrashDetection {
+	return CrashDetection{
+		targetId:    targetNode,
+		crashedNode: recoveredNode,
+		status:      true,
+
+		callback: callback,
+
+		evtId: EventId(fmt.Sprint("RecoveryDetection", targetNode, recoveredNode)),
+	}
+}
+
 func (cd CrashDetection) String() string {
+	if cd.status {
+		return fmt.Sprintf("{RecoveryDetection Target: %v. Recovered Node: %v}", cd.targetId, cd.crashedNode)
+	}
 	return fmt.Sprintf("{CrashDetection Target: %v. Crashed Node: %v}", cd.targetId, cd.crashedNode)
 }
 
@@ -51,7 +73,7 @@ func (cd CrashDetection) Id() EventId {
 //
 // Calls the provided callback function
 func (cd CrashDetection) Execute(node any, errorChan chan error) {
-	cd.callback(cd.crashedNode, false)
+	cd.callback(cd.crashedNode, cd.status)
 	errorChan <- nil
 }
 
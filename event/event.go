@@ -29,6 +29,16 @@ type MessageEvent interface {
 	From() int
 }
 
+// Implemented by message events that hold resources that must be released if the message is never delivered.
+//
+// e.g. a goroutine waiting for the message to be sent.
+type DroppableEvent interface {
+	MessageEvent
+
+	// Release the resources held by the message without delivering it.
+	Drop()
+}
+
 // Compares two events
 //
 // Returns true of both events have the same id or if both are nil.
@@ -0,0 +1,61 @@
+package event
+
+import (
+	"fmt"
+)
+
+// An event representing a goroutine started by a node running.
+//
+// It is analogous to the go statement. The goroutine runs while executing the event, so it is atomic with respect to the other events of the node.
+type GoroutineEvent struct {
+	target int
+	caller string
+	f      func()
+
+	id EventId
+}
+
+// Create a GoroutineEvent
+//
+// target is the id of the node that started the goroutine.
+// caller is a string representing the location in the code where the goroutine was started, and seq is the number of goroutines the node has started from the same location.
+// Together they give a stable id to the event, so that runs can be replayed.
+// f is called when the event is executed.
+func NewGoroutineEvent(target int, caller string, seq int, f func()) GoroutineEvent {
+	return GoroutineEvent{
+		target: target,
+		caller: caller,
+		f:      f,
+
+		id: EventId(fmt.Sprint("Goroutine ", target, " ", caller, " ", seq)),
+	}
+}
+
+// An id that identifies the event.
+// Two events that provided the same input state results in the same output state should have the same id
+//
+// New event implementations should include a identifier of the event type to prevent accidental collisions with other implementations
+func (ge GoroutineEvent) Id() EventId {
+	return ge.id
+}
+
+// A method executing the event.
+//
+// Runs the goroutine.
+//
+// The event will be executed on a separate goroutine.
+// It should signal on the channel if it is clear for the simulator to proceed to processing of the state and the next event.
+// Panics raised while executing the event is recovered by the simulator and returned as errors
+func (ge GoroutineEvent) Execute(_ any, errorChan chan error) {
+	ge.f()
+	errorChan <- nil
+}
+
+// The id of the target node, i.e. the node whose state will be changed by the event executing.
+func (ge GoroutineEvent) Target() int {
+	return ge.target
+}
+
+func (ge GoroutineEvent) String() string {
+	return fmt.Sprintf("{Goroutine Target: %v, Caller: %v}", ge.target, ge.caller)
+}
@@ -0,0 +1,157 @@
+package event
+
+import (
+	"fmt"
+)
+
+// An Event representing the request of a synchronous gRPC call arriving at the server.
+//
+// A synchronous RPC call is one where the calling node blocks until it receives the response.
+// e.g. resp, err := ExampleRpcServer.Foo(...)
+//
+// The request is withheld by an interceptor.
+// When the event is executed the request is sent to the server and handled,
+// and the response is withheld until it is delivered by a GrpcResponseEvent.
+type GrpcRequestEvent struct {
+	from    int
+	target  int
+	method  string
+	deliver func()
+	drop    func()
+
+	id EventId
+}
+
+// Create a new GrpcRequestEvent
+//
+// from is the id of the node making the call, to is the id of the server.
+// method is the full name of the gRPC method and payload is a stable representation of the request, which is used in the id.
+// deliver sends the request to the server and returns when the server has handled it.
+// drop is called instead of deliver if the request is lost.
+func NewGrpcRequestEvent(from int, to int, method string, payload string, deliver func(), drop func()) GrpcRequestEvent {
+	return GrpcRequestEvent{
+		from:    from,
+		target:  to,
+		method:  method,
+		deliver: deliver,
+		drop:    drop,
+
+		id: EventId(fmt.Sprint("GrpcRequest", from, to, method, payload)),
+	}
+}
+
+// An id that identifies the event.
+// Two events that provided the same input state results in the same output state should have the same id
+//
+// New event implementations should include a identifier of the event type to prevent accidental collisions with other implementations
+func (ge GrpcRequestEvent) Id() EventId {
+	return ge.id
+}
+
+// A method executing the event.
+//
+// Send the request to the server and wait until the server has handled it.
+//
+// The event will be executed on a separate goroutine.
+// It should signal on the channel if it is clear for the simulator to proceed to processing of the state and the next event.
+// Panics raised while executing the event is recovered by the simulator and returned as errors
+func (ge GrpcRequestEvent) Execute(node any, errorChan chan error) {
+	ge.deliver()
+	errorChan <- nil
+}
+
+// Lose the request without sending it to the server.
+func (ge GrpcRequestEvent) Drop() {
+	ge.drop()
+}
+
+// The id of the target node, i.e. the node whose state will be changed by the event executing.
+func (ge GrpcRequestEvent) Target() int {
+	return ge.target
+}
+
+func (ge GrpcRequestEvent) String() string {
+	return fmt.Sprintf("GrpcRequest From: %v To: %v Method: %v", ge.from, ge.target, ge.method)
+}
+
+// Returns the id of the node receiving the event
+func (ge GrpcRequestEvent) To() int {
+	return ge.target
+}
+
+// Returns the id of the node sending the event
+func (ge GrpcRequestEvent) From() int {
+	return ge.from
+}
+
+// An Event representing the response of a synchronous gRPC call arriving at the calling node.
+//
+// When the event is executed the calling node is unblocked and continues with the response.
+// The event does not signal that it is completed, since the node continues the execution of the event that made the call.
+type GrpcResponseEvent struct {
+	from    int
+	target  int
+	method  string
+	respond func()
+	drop    func()
+
+	id EventId
+}
+
+// Create a new GrpcResponseEvent
+//
+// from is the id of the server, to is the id of the node that made the call.
+// method is the full name of the gRPC method and result is a stable representation of the response or the error returned by the call.
+// respond unblocks the calling node.
+// drop is called instead of respond if the response is lost.
+func NewGrpcResponseEvent(from int, to int, method string, result string, respond func(), drop func()) GrpcResponseEvent {
+	return GrpcResponseEvent{
+		from:    from,
+		target:  to,
+		method:  method,
+		respond: respond,
+		drop:    drop,
+
+		id: EventId(fmt.Sprint("GrpcResponse", from, to, method, result)),
+	}
+}
+
+// An id that identifies the event.
+// Two events that provided the same input state results in the same output state should have the same id
+//
+// New event implementations should include a identifier of the event type to prevent accidental collisions with other implementations
+func (ge GrpcResponseEvent) Id() EventId {
+	return ge.id
+}
+
+// A method executing the event.
+//
+// Unblock the calling node with the response.
+// Don't signal on the error channel since the calling node continues the event that made the call, and signals when it is completed.
+func (ge GrpcResponseEvent) Execute(node any, _ chan error) {
+	ge.respond()
+}
+
+// Lose the response without unblocking the calling node.
+func (ge GrpcResponseEvent) Drop() {
+	ge.drop()
+}
+
+// The id of the target node, i.e. the node whose state will be changed by the event executing.
+func (ge GrpcResponseEvent) Target() int {
+	return ge.target
+}
+
+func (ge GrpcResponseEvent) String() string {
+	return fmt.Sprintf("GrpcResponse From: %v To: %v Method: %v", ge.from, ge.target, ge.method)
+}
+
+// Returns the id of the node receiving the event
+func (ge GrpcResponseEvent) To() int {
+	return ge.target
+}
+
+// Returns the id of the node sending the event
+func (ge GrpcResponseEvent) From() int {
+	return ge.from
+}
@@ -24,16 +24,16 @@ type GrpcEvent struct {
 //
 // from is teh id of the node sending the message, to is the id of the node receiving it.
 // method is a string representation of the msg used.
-// msg is the message sent.
+// payload is a stable representation of the message sent, which is used in the id.
 // wait is a channel that will be used to represent that the message can be sent to the target node.
-func NewGrpcEvent(from int, to int, method string, msg interface{}, wait chan bool) GrpcEvent {
+func NewGrpcEvent(from int, to int, method string, payload string, wait chan bool) GrpcEvent {
 	return GrpcEvent{
 		target: to,
 		from:   from,
 		method: method,
 		wait:   wait,
 
-		id: EventId(fmt.Sprint("GrpcEvent", from, to, method, msg)),
+		id: EventId(fmt.Sprint("GrpcEvent", from, to, method, payload)),
 	}
 }
 
@@ -56,6 +56,13 @@ func (ge GrpcEvent) Execute(node any, errorChan chan error) {
 	ge.wait <- true
 }
 
+// Release the message without sending it to the target node.
+//
+// The call returns an error, and does not signal that the event is completed.
+func (ge GrpcEvent) Drop() {
+	ge.wait <- false
+}
+
 // The id of the target node, i.e. the node whose state will be changed by the event executing.
 func (ge GrpcEvent) Target() int {
 	return ge.target
@@ -0,0 +1,67 @@
+package event
+
+import (
+	"fmt"
+)
+
+// An Event representing an operation on a gRPC stream arriving at the other end of the stream.
+//
+// The operations on a stream are opening the stream, sending a message and closing the stream.
+// Operations are withheld by interceptors, and the operations in each direction of a stream are delivered in order.
+type GrpcStreamEvent struct {
+	from    int
+	target  int
+	method  string
+	kind    string
+	deliver func() bool
+
+	id EventId
+}
+
+// Create a new GrpcStreamEvent
+//
+// from is the id of the node performing the operation, to is the id of the node at the other end of the stream.
+// method is the full name of the gRPC method and stream is the number of the stream among the streams of the method.
+// kind is the kind of operation, seq is the sequence number of the operation in its direction of the stream
+// and payload is a stable representation of the message of the operation.
+// deliver performs the operation, and returns true if the event is completed.
+// Otherwise the receiving node signals that the event is completed when it has handled the operation.
+func NewGrpcStreamEvent(from int, to int, method string, stream int, kind string, seq int, payload string, deliver func() bool) GrpcStreamEvent {
+	return GrpcStreamEvent{
+		from:    from,
+		target:  to,
+		method:  method,
+		kind:    kind,
+		deliver: deliver,
+
+		id: EventId(fmt.Sprint("GrpcStream ", from, "-", to, " ", method, " ", stream, " ", kind, " ", seq, " ", payload)),
+	}
+}
+
+// An id that identifies the event.
+// Two events that provided the same input state results in the same output state should have the same id
+//
+// New event implementations should include a identifier of the event type to prevent accidental collisions with other implementations
+func (ge GrpcStreamEvent) Id() EventId {
+	return ge.id
+}
+
+// A method executing the event.
+//
+// Perform the operation on the stream.
+// Only signal on the error channel if the receiving node is not handling the operation.
+// Otherwise the receiving node signals when it has handled the operation.
+func (ge GrpcStreamEvent) Execute(node any, errorChan chan error) {
+	if ge.deliver() {
+		errorChan <- nil
+	}
+}
+
+// The id of the target node, i.e. the node whose state will be changed by the event executing.
+func (ge GrpcStreamEvent) Target() int {
+	return ge.target
+}
+
+func (ge GrpcStreamEvent) String() string {
+	return fmt.Sprintf("GrpcStream %v From: %v To: %v Method: %v", ge.kind, ge.from, ge.target, ge.method)
+}
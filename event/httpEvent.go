@@ -0,0 +1,155 @@
+package event
+
+import (
+	"fmt"
+)
+
+// An Event representing an HTTP request arriving at the server.
+//
+// The request is withheld by the RoundTripper of the client, which blocks the calling node until the response is delivered.
+// When the event is executed the request is sent to the server and handled,
+// and the response is withheld until it is delivered by a HttpResponseEvent.
+type HttpRequestEvent struct {
+	from    int
+	target  int
+	method  string
+	path    string
+	deliver func()
+	drop    func()
+
+	id EventId
+}
+
+// Create a new HttpRequestEvent
+//
+// from is the id of the node making the request, to is the id of the server.
+// method is the HTTP method, path is the path and query of the URL, and body is a stable representation of the body of the request.
+// deliver sends the request to the server and returns when the server has handled it.
+// drop is called instead of deliver if the request is lost.
+func NewHttpRequestEvent(from int, to int, method string, path string, body string, deliver func(), drop func()) HttpRequestEvent {
+	return HttpRequestEvent{
+		from:    from,
+		target:  to,
+		method:  method,
+		path:    path,
+		deliver: deliver,
+		drop:    drop,
+
+		id: EventId(fmt.Sprint("HttpRequest ", from, "-", to, " ", method, " ", path, " ", body)),
+	}
+}
+
+// An id that identifies the event.
+// Two events that provided the same input state results in the same output state should have the same id
+//
+// New event implementations should include a identifier of the event type to prevent accidental collisions with other implementations
+func (he HttpRequestEvent) Id() EventId {
+	return he.id
+}
+
+// A method executing the event.
+//
+// Send the request to the server and wait until the server has handled it.
+func (he HttpRequestEvent) Execute(node any, errorChan chan error) {
+	he.deliver()
+	errorChan <- nil
+}
+
+// Lose the request without sending it to the server.
+func (he HttpRequestEvent) Drop() {
+	he.drop()
+}
+
+// The id of the target node, i.e. the node whose state will be changed by the event executing.
+func (he HttpRequestEvent) Target() int {
+	return he.target
+}
+
+func (he HttpRequestEvent) String() string {
+	return fmt.Sprintf("HttpRequest From: %v To: %v %v %v", he.from, he.target, he.method, he.path)
+}
+
+// Returns the id of the node receiving the event
+func (he HttpRequestEvent) To() int {
+	return he.target
+}
+
+// Returns the id of the node sending the event
+func (he HttpRequestEvent) From() int {
+	return he.from
+}
+
+// An Event representing the response to an HTTP request arriving at the node that made the request.
+//
+// When the event is executed the calling node is unblocked and continues with the response.
+// The event does not signal that it is completed, since the node continues the execution of the event that made the request.
+type HttpResponseEvent struct {
+	from    int
+	target  int
+	method  string
+	path    string
+	respond func()
+	drop    func()
+
+	id EventId
+}
+
+// Create a new HttpResponseEvent
+//
+// from is the id of the server, to is the id of the node that made the request.
+// method and path are the HTTP method and the path of the request.
+// result is a stable representation of the status and the body of the response, or of the error returned by the request.
+// respond unblocks the calling node.
+// drop is called instead of respond if the response is lost.
+func NewHttpResponseEvent(from int, to int, method string, path string, result string, respond func(), drop func()) HttpResponseEvent {
+	return HttpResponseEvent{
+		from:    from,
+		target:  to,
+		method:  method,
+		path:    path,
+		respond: respond,
+		drop:    drop,
+
+		id: EventId(fmt.Sprint("HttpResponse ", from, "-", to, " ", method, " ", path, " ", result)),
+	}
+}
+
+// An id that identifies the event.
+// Two events that provided the same input state results in the same output state should have the same id
+//
+// New event implementations should include a identifier of the event type to prevent accidental collisions with other implementations
+func (he HttpResponseEvent) Id() EventId {
+	return he.id
+}
+
+// A method executing the event.
+//
+// Unblock the calling node with the response.
+// Don't signal on the error channel since the calling node continues the event that made the request, and signals when it is completed.
+func (he HttpResponseEvent) Execute(node any, _ chan error) {
+	he.respond()
+}
+
+// Lose the response without unblocking the calling node.
+func (he HttpResponseEvent) Drop() {
+	he.drop()
+}
+
+// The id of the target node, i.e. the node whose state will be changed by the event executing.
+func (he HttpResponseEvent) Target() int {
+	return he.target
+}
+
+func (he HttpResponseEvent) String() string {
+	return fmt.Sprintf("HttpResponse From: %v To: %v %v %v", he.from, he.target, he.method, he.path)
+}
+
+// Returns the id of the node receiving the event
+func (he HttpResponseEvent) To() int {
+	return he.target
+}
+
+// Returns the id of the node sending the event
+func (he HttpResponseEvent) From() int {
+	return he.from
+}
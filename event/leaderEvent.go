@@ -0,0 +1,114 @@
+package event
+
+import "fmt"
+
+// Represent the leader oracle changing the leader to the target node
+type LeaderChange struct {
+	target int
+	epoch  int
+	change func(leader int, epoch int) error
+
+	id EventId
+}
+
+// Create a LeaderChange
+//
+// target is the id of the new leader.
+// epoch is the number of leader changes that has happened before the change. Used to discard changes that are no longer valid.
+// change is a function that will be called when the event is executed.
+func NewLeaderChange(target int, epoch int, change func(int, int) error) LeaderChange {
+	return LeaderChange{
+		target: target,
+		epoch:  epoch,
+		change: change,
+
+		id: EventId(fmt.Sprint("LeaderChange", target, "-", epoch)),
+	}
+}
+
+// An id that identifies the event.
+// Two events that provided the same input state results in the same output state should have the same id
+//
+// New event implementations should include a identifier of the event type to prevent accidental collisions with other implementations
+func (lc LeaderChange) Id() EventId {
+	return lc.id
+}
+
+// A method executing the event.
+//
+// Calls the change function with the target id and the epoch.
+//
+// The event will be executed on a separate goroutine.
+// It should signal on the channel if it is clear for the simulator to proceed to processing of the state and the next event.
+// Panics raised while executing the event is recovered by the simulator and returned as errors
+func (lc LeaderChange) Execute(_ any, evtChan chan error) {
+	evtChan <- lc.change(lc.target, lc.epoch)
+}
+
+// The id of the target node, i.e. the node whose state will be changed by the event executing.
+func (lc LeaderChange) Target() int {
+	return lc.target
+}
+
+func (lc LeaderChange) String() string {
+	return fmt.Sprintf("{LeaderChange Leader: %v. Epoch: %v}", lc.target, lc.epoch)
+}
+
+// Event representing the target node learning that the leader oracle trusts a new leader.
+//
+// When the event is executed the callback is called with the id of the leader.
+type LeaderNotification struct {
+	targetId int
+	leader   int
+	epoch    int
+
+	callback func(leader int)
+
+	evtId EventId
+}
+
+// Create a LeaderNotification
+//
+// targetNode is the id of the node that is notified.
+// leader is the id of the trusted leader.
+// epoch is the number of leader changes that has happened before the leader was trusted.
+// callback is the function that will be called with the id of the leader.
+func NewLeaderNotification(targetNode int, leader int, epoch int, callback func(int)) LeaderNotification {
+	return LeaderNotification{
+		targetId: targetNode,
+		leader:   leader,
+		epoch:    epoch,
+
+		callback: callback,
+
+		evtId: EventId(fmt.Sprint("LeaderNotification", targetNode, "-", leader, "-", epoch)),
+	}
+}
+
+func (ln LeaderNotification) String() string {
+	return fmt.Sprintf("{LeaderNotification Target: %v. Leader: %v}", ln.targetId, ln.leader)
+}
+
+// An id that identifies the event.
+// Two events that provided the same input state results in the same output state should have the same id
+//
+// New event implementations should include a identifier of the event type to prevent accidental collisions with other implementations
+func (ln LeaderNotification) Id() EventId {
+	return ln.evtId
+}
+
+// A method executing the event.
+// The event will be executed on a separate goroutine.
+// It should signal on the channel if it is clear for the simulator to proceed to processing of the state and the next event.
+// Panics raised while executing the event is recovered by the simulator and returned as errors
+//
+// Calls the provided callback function
+func (ln LeaderNotification) Execute(node any, errorChan chan error) {
+	ln.callback(ln.leader)
+	errorChan <- nil
+}
+
+// The id of the target node, i.e. the node whose state will be changed by the event executing.
+func (ln LeaderNotification) Target() int {
+	return ln.targetId
+}
@@ -0,0 +1,70 @@
+package event
+
+import "fmt"
+
+// An event representing a fault in the network affecting a message, e.g. the message being lost or duplicated.
+//
+// The event is added as an alternative to the delivery of the message.
+// When the event is executed the fault function is called, which performs the fault if it is still possible.
+type MessageFault struct {
+	kind   string
+	target int
+	from   int
+	fault  func()
+
+	id EventId
+}
+
+// Create an event representing the message being lost.
+//
+// msg is the message that is lost. drop is called when the event is executed.
+func NewMessageDrop(msg MessageEvent, drop func()) MessageFault {
+	return newMessageFault("Drop", msg, drop)
+}
+
+// Create an event representing the message being duplicated.
+//
+// msg is the message that is duplicated. duplicate is called when the event is executed.
+func NewMessageDuplicate(msg MessageEvent, duplicate func()) MessageFault {
+	return newMessageFault("Duplicate", msg, duplicate)
+}
+
+func newMessageFault(kind string, msg MessageEvent, fault func()) MessageFault {
+	return MessageFault{
+		kind:   kind,
+		target: msg.To(),
+		from:   msg.From(),
+		fault:  fault,
+
+		id: EventId(fmt.Sprint(kind, " ", msg.Id())),
+	}
+}
+
+// An id that identifies the event.
+// Two events that provided the same input state results in the same output state should have the same id
+//
+// New event implementations should include a identifier of the event type to prevent accidental collisions with other implementations
+func (mf MessageFault) Id() EventId {
+	return mf.id
+}
+
+// A method executing the event.
+//
+// Calls the fault function.
+//
+// The event will be executed on a separate goroutine.
+// It should signal on the channel if it is clear for the simulator to proceed to processing of the state and the next event.
+// Panics raised while executing the event is recovered by the simulator and returned as errors
+func (mf MessageFault) Execute(_ any, errorChan chan error) {
+	mf.fault()
+	errorChan <- nil
+}
+
+// The id of the target node, i.e. the node whose state will be changed by the event executing.
+func (mf MessageFault) Target() int {
+	return mf.target
+}
+
+func (mf MessageFault) String() string {
+	return fmt.Sprintf("{%v From: %v, To: %v}", mf.kind, mf.from, mf.target)
+}
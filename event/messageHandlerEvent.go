@@ -78,3 +78,17 @@ func (me MessageHandlerEvent) To() int {
 func (me MessageHandlerEvent) From() int {
 	return me.from
 }
+
+// The name of the message handler method that is called when the message arrives
+func (me MessageHandlerEvent) Type() string {
+	return me.msgType
+}
+
+// The parameters that are passed to the message handler method
+func (me MessageHandlerEvent) Params() []any {
+	params := make([]any, len(me.params))
+	for i, val := range me.params {
+		params[i] = val.Interface()
+	}
+	return params
+}
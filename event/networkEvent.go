@@ -0,0 +1,65 @@
+package event
+
+import (
+	"fmt"
+)
+
+// An Event representing data sent on an in-memory connection arriving at the other end of the connection.
+//
+// The operations on a connection are writing data, closing the connection and resetting the connection when a node crashes.
+// The operations in each direction of a connection are delivered in order.
+type NetworkEvent struct {
+	from    int
+	target  int
+	kind    string
+	deliver func() bool
+
+	id EventId
+}
+
+// Create a new NetworkEvent
+//
+// from is the id of the node performing the operation, to is the id of the node at the other end of the connection.
+// conn identifies the connection among the connections between the nodes.
+// kind is the kind of operation, seq is the sequence number of the operation in its direction of the connection
+// and payload is a stable representation of the data of the operation.
+// deliver performs the operation, and returns true if the event is completed.
+// Otherwise the receiving node signals that the event is completed when it has handled the operation.
+func NewNetworkEvent(from int, to int, conn string, kind string, seq int, payload string, deliver func() bool) NetworkEvent {
+	return NetworkEvent{
+		from:    from,
+		target:  to,
+		kind:    kind,
+		deliver: deliver,
+
+		id: EventId(fmt.Sprint("Network ", from, "-", to, " ", conn, " ", kind, " ", seq, " ", payload)),
+	}
+}
+
+// An id that identifies the event.
+// Two events that provided the same input state results in the same output state should have the same id
+//
+// New event implementations should include a identifier of the event type to prevent accidental collisions with other implementations
+func (ne NetworkEvent) Id() EventId {
+	return ne.id
+}
+
+// A method executing the event.
+//
+// Perform the operation on the connection.
+// Only signal on the error channel if the receiving node is not handling the operation.
+// Otherwise the receiving node signals when it has handled the operation.
+func (ne NetworkEvent) Execute(node any, errorChan chan error) {
+	if ne.deliver() {
+		errorChan <- nil
+	}
+}
+
+// The id of the target node, i.e. the node whose state will be changed by the event executing.
+func (ne NetworkEvent) Target() int {
+	return ne.target
+}
+
+func (ne NetworkEvent) String() string {
+	return fmt.Sprintf("Network %v From: %v To: %v", ne.kind, ne.from, ne.target)
+}
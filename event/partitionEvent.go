@@ -0,0 +1,74 @@
+package event
+
+import (
+	"fmt"
+)
+
+// Represent the network being partitioned or healed.
+//
+// The partition is identified by its index in the list of partitions configured for the failure manager.
+type PartitionEvent struct {
+	kind      string
+	target    int
+	partition int
+	f         func(int) error
+
+	id EventId
+}
+
+// Create an event partitioning the network
+//
+// target is the id of a node in the partition. Since the partition affects the entire network the target is only used by the simulator to look up a node.
+// partition is the index of the partition.
+// partitionFunc is a function that will be called with the index of the partition when the event is executed.
+func NewPartitionEvent(target int, partition int, partitionFunc func(int) error) PartitionEvent {
+	return newPartitionEvent("Partition", target, partition, partitionFunc)
+}
+
+// Create an event healing the partition of the network
+//
+// target is the id of a node in the partition. Since the partition affects the entire network the target is only used by the simulator to look up a node.
+// partition is the index of the partition.
+// heal is a function that will be called with the index of the partition when the event is executed.
+func NewHealEvent(target int, partition int, heal func(int) error) PartitionEvent {
+	return newPartitionEvent("Heal", target, partition, heal)
+}
+
+func newPartitionEvent(kind string, target int, partition int, f func(int) error) PartitionEvent {
+	return PartitionEvent{
+		kind:      kind,
+		target:    target,
+		partition: partition,
+		f:         f,
+
+		id: EventId(fmt.Sprint(kind, partition)),
+	}
+}
+
+// An id that identifies the event.
+// Two events that provided the same input state results in the same output state should have the same id
+//
+// New event implementations should include a identifier of the event type to prevent accidental collisions with other implementations
+func (pe PartitionEvent) Id() EventId {
+	return pe.id
+}
+
+// A method executing the event.
+//
+// Calls the function of the event with the index of the partition.
+//
+// The event will be executed on a separate goroutine.
+// It should signal on the channel if it is clear for the simulator to proceed to processing of the state and the next event.
+// Panics raised while executing the event is recovered by the simulator and returned as errors
+func (pe PartitionEvent) Execute(_ any, evtChan chan error) {
+	evtChan <- pe.f(pe.partition)
+}
+
+// The id of the target node, i.e. the node whose state will be changed by the event executing.
+func (pe PartitionEvent) Target() int {
+	return pe.target
+}
+
+func (pe PartitionEvent) String() string {
+	return fmt.Sprintf("{%v Partition: %v}", pe.kind, pe.partition)
+}
@@ -0,0 +1,65 @@
+package event
+
+import (
+	"fmt"
+)
+
+// An event representing the outcome of a random draw on a node.
+//
+// Each possible outcome of a draw is a separate event, and the simulator chooses the outcome by executing one of them.
+// Only the first of the events of a draw that is executed delivers its value. The others have no effect.
+type RandomEvent struct {
+	target int
+	draw   int
+	value  int64
+	choose func(int64) bool
+
+	id EventId
+}
+
+// Create a RandomEvent
+//
+// target is the id of the node drawing the value.
+// draw is the sequence number of the draw on the node, and value is the outcome represented by the event.
+// choose is called with the value when the event is executed. It should deliver the value to the node and return true, or return false if another outcome of the draw has been chosen.
+func NewRandomEvent(target int, draw int, value int64, choose func(int64) bool) RandomEvent {
+	return RandomEvent{
+		target: target,
+		draw:   draw,
+		value:  value,
+		choose: choose,
+
+		id: EventId(fmt.Sprint("Random ", target, "-", draw, " ", value)),
+	}
+}
+
+// An id that identifies the event.
+// Two events that provided the same input state results in the same output state should have the same id
+//
+// New event implementations should include a identifier of the event type to prevent accidental collisions with other implementations
+func (re RandomEvent) Id() EventId {
+	return re.id
+}
+
+// A method executing the event.
+//
+// Delivers the value to the node waiting for the draw.
+// If the value is delivered the node that continues after the draw signals when it has completed, like the SleepEvent.
+//
+// The event will be executed on a separate goroutine.
+// It should signal on the channel if it is clear for the simulator to proceed to processing of the state and the next event.
+// Panics raised while executing the event is recovered by the simulator and returned as errors
+func (re RandomEvent) Execute(_ any, errorChan chan error) {
+	if !re.choose(re.value) {
+		errorChan <- nil
+	}
+}
+
+// The id of the target node, i.e. the node whose state will be changed by the event executing.
+func (re RandomEvent) Target() int {
+	return re.target
+}
+
+func (re RandomEvent) String() string {
+	return fmt.Sprintf("{Random Target: %v, Draw: %v, Value: %v}", re.target, re.draw, re.value)
+}
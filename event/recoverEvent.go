@@ -0,0 +1,54 @@
+package event
+
+import (
+	"fmt"
+)
+
+// Represent the target node recovering after a crash
+type RecoverEvent struct {
+	target  int
+	recover func(int) error
+
+	id EventId
+}
+
+// Create a RecoverEvent
+//
+// target is the id of the target node.
+// recover is a function that will be called when the event is executed.
+func NewRecoverEvent(target int, recover func(int) error) RecoverEvent {
+	return RecoverEvent{
+		target:  target,
+		recover: recover,
+
+		id: EventId(fmt.Sprint("Recover", target)),
+	}
+}
+
+// An id that identifies the event.
+// Two events that provided the same input state results in the same output state should have the same id
+//
+// New event implementations should include a identifier of the event type to prevent accidental collisions with other implementations
+func (re RecoverEvent) Id() EventId {
+	return re.id
+}
+
+// A method executing the event.
+//
+// Calls the recover function with the target id.
+//
+// The event will be executed on a separate goroutine.
+// It should signal on the channel if it is clear for the simulator to proceed to processing of the state and the next event.
+// Panics raised while executing the event is recovered by the simulator and returned as errors
+func (re RecoverEvent) Execute(_ any, evtChan chan error) {
+	evtChan <- re.recover(re.target)
+}
+
+// The id of the target node, i.e. the node whose state will be changed by the event executing.
+func (re RecoverEvent) Target() int {
+	return re.target
+}
+
+func (re RecoverEvent) String() string {
+	return fmt.Sprintf("{Recover Target: %v}", re.target)
+}
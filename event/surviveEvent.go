@@ -0,0 +1,57 @@
+package event
+
+import (
+	"fmt"
+)
+
+// Represent the choice that the target node does not crash in the run.
+//
+// Is added as an alternative to a CrashEvent when the crash of the node is a nondeterministic choice.
+type SurviveEvent struct {
+	target  int
+	survive func(int)
+
+	id EventId
+}
+
+// Create a SurviveEvent
+//
+// target is the id of the target node.
+// survive is a function that will be called when the event is executed.
+func NewSurviveEvent(target int, survive func(int)) SurviveEvent {
+	return SurviveEvent{
+		target:  target,
+		survive: survive,
+
+		id: EventId(fmt.Sprint("Survive", target)),
+	}
+}
+
+// An id that identifies the event.
+// Two events that provided the same input state results in the same output state should have the same id
+//
+// New event implementations should include a identifier of the event type to prevent accidental collisions with other implementations
+func (se SurviveEvent) Id() EventId {
+	return se.id
+}
+
+// A method executing the event.
+//
+// Call the survive function with the target id.
+//
+// The event will be executed on a separate goroutine.
+// It should signal on the channel if it is clear for the simulator to proceed to processing of the state and the next event.
+// Panics raised while executing the event is recovered by the simulator and returned as errors
+func (se SurviveEvent) Execute(_ any, evtChan chan error) {
+	se.survive(se.target)
+	evtChan <- nil
+}
+
+// The id of the target node, i.e. the node whose state will be changed by the event executing.
+func (se SurviveEvent) Target() int {
+	return se.target
+}
+
+func (se SurviveEvent) String() string {
+	return fmt.Sprintf("{Survive Target: %v}", se.target)
+}
@@ -0,0 +1,75 @@
+package event
+
+import "fmt"
+
+// Event representing a change in whether a node is suspected by the target node, without the suspected node changing status.
+//
+// Used to imitate failure detectors that can falsely suspect a correct node, and later restore it.
+// When the event is executed the callback is called with the id of the suspected node and the new status of the node.
+// The status is false if the node is suspected and true if it is restored.
+type SuspicionEvent struct {
+	targetId      int
+	suspectedNode int
+	status        bool
+
+	callback func(id int, status bool)
+
+	evtId EventId
+}
+
+// Create an event representing the target node falsely suspecting the suspectedNode.
+func NewFalseSuspicion(targetNode int, suspectedNode int, callback func(int, bool)) SuspicionEvent {
+	return SuspicionEvent{
+		targetId:      targetNode,
+		suspectedNode: suspectedNode,
+		status:        false,
+
+		callback: callback,
+
+		evtId: EventId(fmt.Sprint("FalseSuspicion", targetNode, suspectedNode)),
+	}
+}
+
+// Create an event representing the target node restoring the previously suspected suspectedNode.
+func NewRestore(targetNode int, suspectedNode int, callback func(int, bool)) SuspicionEvent {
+	return SuspicionEvent{
+		targetId:      targetNode,
+		suspectedNode: suspectedNode,
+		status:        true,
+
+		callback: callback,
+
+		evtId: EventId(fmt.Sprint("Restore", targetNode, suspectedNode)),
+	}
+}
+
+func (se SuspicionEvent) String() string {
+	if se.status {
+		return fmt.Sprintf("{Restore Target: %v. Restored Node: %v}", se.targetId, se.suspectedNode)
+	}
+	return fmt.Sprintf("{FalseSuspicion Target: %v. Suspected Node: %v}", se.targetId, se.suspectedNode)
+}
+
+// An id that identifies the event.
+// Two events that provided the same input state results in the same output state should have the same id
+//
+// New event implementations should include a identifier of the event type to prevent accidental collisions with other implementations
+func (se SuspicionEvent) Id() EventId {
+	return se.evtId
+}
+
+// A method executing the event.
+// The event will be executed on a separate goroutine.
+// It should signal on the channel if it is clear for the simulator to proceed to processing of the state and the next event.
+// Panics raised while executing the event is recovered by the simulator and returned as errors
+//
+// Calls the provided callback function
+func (se SuspicionEvent) Execute(node any, errorChan chan error) {
+	se.callback(se.suspectedNode, se.status)
+	errorChan <- nil
+}
+
+// The id of the target node, i.e. the node whose state will be changed by the event executing.
+func (se SuspicionEvent) Target() int {
+	return se.targetId
+}
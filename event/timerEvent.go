@@ -0,0 +1,65 @@
+package event
+
+import (
+	"fmt"
+	"time"
+)
+
+// An event representing a timer expiring.
+//
+// It is analogous to the expiration of a time.Timer or the tick of a time.Ticker.
+type TimerEvent struct {
+	target   int
+	timer    int
+	duration time.Duration
+	fire     func()
+
+	id EventId
+}
+
+// Create a TimerEvent
+//
+// target is the id of the node that owns the timer.
+// timer is the sequence number of the timer on the node, and generation is the number of times the timer has been started.
+// Together they give a stable id to the event, so that runs can be replayed.
+// duration is the duration the timer was started with.
+// fire is called when the event is executed.
+func NewTimerEvent(target int, timer int, generation int, duration time.Duration, fire func()) TimerEvent {
+	return TimerEvent{
+		target:   target,
+		timer:    timer,
+		duration: duration,
+		fire:     fire,
+
+		id: EventId(fmt.Sprint("Timer ", target, "-", timer, "-", generation)),
+	}
+}
+
+// An id that identifies the event.
+// Two events that provided the same input state results in the same output state should have the same id
+//
+// New event implementations should include a identifier of the event type to prevent accidental collisions with other implementations
+func (te TimerEvent) Id() EventId {
+	return te.id
+}
+
+// A method executing the event.
+//
+// Calls the fire function of the timer.
+//
+// The event will be executed on a separate goroutine.
+// It should signal on the channel if it is clear for the simulator to proceed to processing of the state and the next event.
+// Panics raised while executing the event is recovered by the simulator and returned as errors
+func (te TimerEvent) Execute(_ any, errorChan chan error) {
+	te.fire()
+	errorChan <- nil
+}
+
+// The id of the target node, i.e. the node whose state will be changed by the event executing.
+func (te TimerEvent) Target() int {
+	return te.target
+}
+
+func (te TimerEvent) String() string {
+	return fmt.Sprintf("{Timer Target: %v, Timer: %v, Duration: %v}", te.target, te.timer, te.duration)
+}
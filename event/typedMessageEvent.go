@@ -0,0 +1,90 @@
+package event
+
+import (
+	"fmt"
+)
+
+// An event representing the arrival of a typed message on a node.
+//
+// Calls a handler that has been registered for the type of the message, without using reflection.
+// The handler is called with the target node, the id of the sending node and the message.
+// Implements the MessageEvent interface
+type TypedMessageEvent[T, M any] struct {
+	from    int
+	to      int
+	msgType string
+	msg     M
+	handler func(*T, int, M)
+
+	id EventId
+}
+
+// Creates a TypedMessageEvent
+//
+// from is the id of the sending node, to is the id of the receiving node.
+// msgType is the name of the message type, handler is the function that is called when the message arrives.
+// The id of the event is the same as the id of a MessageHandlerEvent with msg as the only parameter.
+func NewTypedMessageEvent[T, M any](from, to int, msgType string, msg M, handler func(*T, int, M)) TypedMessageEvent[T, M] {
+	return TypedMessageEvent[T, M]{
+		from:    from,
+		to:      to,
+		msgType: msgType,
+		msg:     msg,
+		handler: handler,
+
+		id: EventId(fmt.Sprint("Message ", from, to, msgType, []any{msg})),
+	}
+}
+
+// An id that identifies the event.
+// Two events that provided the same input state results in the same output state should have the same id
+//
+// New event implementations should include a identifier of the event type to prevent accidental collisions with other implementations
+func (te TypedMessageEvent[T, M]) Id() EventId {
+	return te.id
+}
+
+func (te TypedMessageEvent[T, M]) String() string {
+	return fmt.Sprintf("{From: %v, To: %v, Type: %s}", te.from, te.to, te.msgType)
+}
+
+// A method executing the event.
+// The event will be executed on a separate goroutine.
+// It should signal on the channel if it is clear for the simulator to proceed to processing of the state and the next event.
+// Panics raised while executing the event is recovered by the simulator and returned as errors
+//
+// Calls the handler of the message with the target node, which must be of type *T.
+func (te TypedMessageEvent[T, M]) Execute(node any, nextEvt chan error) {
+	n, ok := node.(*T)
+	if !ok {
+		nextEvt <- fmt.Errorf("TypedMessageEvent: Expected the target node to be of type %T. Got: %T", n, node)
+		return
+	}
+	te.handler(n, te.from, te.msg)
+	nextEvt <- nil
+}
+
+// The id of the target node, i.e. the node whose state will be changed by the event executing.
+func (te TypedMessageEvent[T, M]) Target() int {
+	return te.to
+}
+
+// The id of the Node that the message is sent to
+func (te TypedMessageEvent[T, M]) To() int {
+	return te.to
+}
+
+// The id of the Node that the message is sent from
+func (te TypedMessageEvent[T, M]) From() int {
+	return te.from
+}
+
+// The name of the message type
+func (te TypedMessageEvent[T, M]) Type() string {
+	return te.msgType
+}
+
+// The message that is passed to the handler
+func (te TypedMessageEvent[T, M]) Message() M {
+	return te.msg
+}
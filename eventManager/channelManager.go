@@ -0,0 +1,165 @@
+package eventManager
+
+import (
+	"fmt"
+	"gomc/event"
+	"sync"
+)
+
+// An Event Manager used to send messages of type M between nodes over channels
+//
+// Each node has an Endpoint, which sends messages to other nodes using Send and receives messages from the channel returned by Recv.
+// Each message is delivered by a ChannelEvent, so the scheduler decides the order in which messages are received.
+// Messages are represented in the ids of the events using fmt.Sprint, so they should not contain pointers.
+//
+// Recv must be called before receiving each message, e.g. by receiving from ep.Recv() in a select statement in the receive loop of the node.
+// The event delivering a message is completed when the node has received the message and calls Recv again, so the handling of each message is atomic.
+// Ranging over the channel returned by Recv is therefore not supported.
+// Messages are not delivered before the node has called Recv.
+//
+// The ChannelManager subscribes to the status updates of the nodes with an endpoint, and discards the messages to a node once it detects its own crash.
+// Messages are delivered to the node until it detects its crash, so the node must keep receiving until then.
+// Crash can also be called directly to discard the messages immediately, e.g. by the function performing the crash on the node, or if the SimulationParameters do not contain CrashSubscribe.
+//
+// If the SimulationParameters contain NetworkFaults, messages can be lost or duplicated.
+// A new ChannelManager must be created for each run.
+type ChannelManager[M any] struct {
+	ea             EventAdder
+	nextEvt        func(error, int)
+	faults         *NetworkFaults
+	crashSubscribe func(int, func(int, bool))
+
+	lock      sync.Mutex
+	endpoints map[int]*Endpoint[M]
+}
+
+// Create a new ChannelManager
+func NewChannelManager[M any](sp SimulationParameters) *ChannelManager[M] {
+	return &ChannelManager[M]{
+		ea:             sp.EventAdder,
+		nextEvt:        sp.NextEvt,
+		faults:         sp.NetworkFaults,
+		crashSubscribe: sp.CrashSubscribe,
+
+		endpoints: make(map[int]*Endpoint[M]),
+	}
+}
+
+// A message received on a channel
+type Envelope[M any] struct {
+	// The id of the node that sent the message
+	From int
+	Msg  M
+}
+
+// Returns the endpoint of the node with the provided id.
+//
+// Returns the same endpoint if it is called multiple times with the same id.
+// The node is subscribed to status updates when its endpoint is created.
+func (cm *ChannelManager[M]) Endpoint(id int) *Endpoint[M] {
+	cm.lock.Lock()
+	ep, ok := cm.endpoints[id]
+	if !ok {
+		ep = &Endpoint[M]{
+			cm: cm,
+			id: id,
+			// Holds the message that is being delivered
+			ch: make(chan Envelope[M], 1),
+		}
+		ep.cond = sync.NewCond(&ep.lock)
+		cm.endpoints[id] = ep
+	}
+	cm.lock.Unlock()
+
+	if !ok && cm.crashSubscribe != nil {
+		cm.crashSubscribe(id, func(crashed int, status bool) {
+			// Other nodes can be falsely suspected, but a node only detects its own crash when it has crashed
+			if crashed == id && !status {
+				cm.Crash(id)
+			}
+		})
+	}
+	return ep
+}
+
+// Discard the messages that are delivered to the crashed node.
+func (cm *ChannelManager[M]) Crash(id int) {
+	ep := cm.Endpoint(id)
+	ep.lock.Lock()
+	defer ep.lock.Unlock()
+	ep.crashed = true
+	ep.cond.Broadcast()
+}
+
+// The endpoint of a node, which sends and receives messages over channels
+type Endpoint[M any] struct {
+	cm *ChannelManager[M]
+	id int
+	ch chan Envelope[M]
+
+	lock sync.Mutex
+	cond *sync.Cond
+	// True if the node has called Recv
+	receiving bool
+	// True if the node must signal that the event delivering the last message is completed
+	pending bool
+	crashed bool
+}
+
+// Send the message to the node with id to.
+//
+// The message is delivered once its event is executed.
+func (ep *Endpoint[M]) Send(to int, msg M) {
+	cm := ep.cm
+	target := cm.Endpoint(to)
+	env := Envelope[M]{From: ep.id, Msg: msg}
+	evt := event.NewChannelEvent(ep.id, to, fmt.Sprint(msg), func() bool {
+		return target.deliver(env)
+	})
+	if cm.faults == nil {
+		cm.ea.AddEvent(evt)
+		return
+	}
+	cm.faults.addMessage(cm.ea, evt, func() {}, func() { cm.ea.AddEvent(evt) })
+}
+
+// Returns the channel that the messages to the node are delivered on.
+//
+// Must be called before receiving each message.
+// Signals that the event delivering the previous message is completed if the message has been received.
+func (ep *Endpoint[M]) Recv() <-chan Envelope[M] {
+	ep.lock.Lock()
+	if !ep.receiving {
+		ep.receiving = true
+		ep.cond.Broadcast()
+	}
+	// The message is still in the channel if the node has not received it yet
+	signal := ep.pending && len(ep.ch) == 0
+	if signal {
+		ep.pending = false
+	}
+	ep.lock.Unlock()
+	if signal {
+		ep.cm.nextEvt(nil, ep.id)
+	}
+	return ep.ch
+}
+
+// Deliver the message on the channel once the node has called Recv.
+//
+// Returns true if the message is discarded, and the event is completed.
+// Otherwise the node signals when it has handled the message.
+func (ep *Endpoint[M]) deliver(env Envelope[M]) bool {
+	ep.lock.Lock()
+	defer ep.lock.Unlock()
+	for !ep.receiving && !ep.crashed {
+		ep.cond.Wait()
+	}
+	if ep.crashed {
+		return true
+	}
+	// The channel is empty, since the previous message has been received before its event was completed
+	ep.ch <- env
+	ep.pending = true
+	return false
+}
@@ -0,0 +1,149 @@
+package eventManager
+
+import (
+	"gomc/event"
+	"testing"
+	"time"
+)
+
+// Execute the last added event and wait for the nextEvt signal completing it
+func deliverLast(t *testing.T, sch *MockScheduler, nextEvt chan error) event.Event {
+	t.Helper()
+	evt, _ := sch.GetEvent()
+	go evt.Execute(nil, nextEvt)
+	select {
+	case err := <-nextEvt:
+		if err != nil {
+			t.Fatalf("Unexpected error executing %v: %v", evt, err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Timed out executing %v", evt)
+	}
+	return evt
+}
+
+func TestChannelDelivery(t *testing.T) {
+	sch, nextEvt, sp := newStreamParameters()
+	cm := NewChannelManager[string](sp)
+	ep0 := cm.Endpoint(0)
+	ep1 := cm.Endpoint(1)
+
+	received := []Envelope[string]{}
+	handling := make(chan struct{})
+	quit := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case env := <-ep1.Recv():
+				<-handling
+				received = append(received, env)
+			case <-quit:
+				return
+			}
+		}
+	}()
+
+	ep0.Send(1, "a")
+	ep0.Send(1, "b")
+	if len(sch.eventStack) != 2 {
+		t.Fatalf("Expected 2 events to be added. Got: %v", sch.eventStack)
+	}
+
+	// The event is not completed until the node has handled the message
+	evt, _ := sch.GetEvent()
+	go evt.Execute(nil, nextEvt)
+	select {
+	case <-nextEvt:
+		t.Fatalf("Expected the event to be completed after the message was handled")
+	case <-time.After(50 * time.Millisecond):
+	}
+	close(handling)
+	select {
+	case err := <-nextEvt:
+		if err != nil {
+			t.Fatalf("Unexpected error executing %v: %v", evt, err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Timed out executing %v", evt)
+	}
+	if evt.Id() != "Channel 0-1 b" {
+		t.Errorf("Unexpected event id: %v", evt.Id())
+	}
+
+	// The scheduler decides the order in which the messages are received
+	deliverLast(t, sch, nextEvt)
+	close(quit)
+	expected := []Envelope[string]{{From: 0, Msg: "b"}, {From: 0, Msg: "a"}}
+	if len(received) != len(expected) {
+		t.Fatalf("Expected to receive %v. Got: %v", expected, received)
+	}
+	for i := range expected {
+		if received[i] != expected[i] {
+			t.Errorf("Expected to receive %v. Got: %v", expected, received)
+		}
+	}
+}
+
+func TestChannelCrash(t *testing.T) {
+	sch, nextEvt, sp := newStreamParameters()
+	cm := NewChannelManager[int](sp)
+	ep1 := cm.Endpoint(1)
+
+	cm.Endpoint(0).Send(1, 1)
+	cm.Crash(1)
+
+	// The message is discarded, even though the node has not called Recv
+	deliverLast(t, sch, nextEvt)
+	select {
+	case env := <-ep1.Recv():
+		t.Errorf("Expected the message to the crashed node to be discarded. Got: %v", env)
+	default:
+	}
+}
+
+func TestChannelCrashSubscribe(t *testing.T) {
+	sch, nextEvt, sp := newStreamParameters()
+	callbacks := map[int]func(int, bool){}
+	sp.CrashSubscribe = func(id int, callback func(int, bool)) { callbacks[id] = callback }
+	cm := NewChannelManager[int](sp)
+	ep1 := cm.Endpoint(1)
+	cm.Endpoint(0).Send(1, 1)
+	if len(callbacks) != 2 {
+		t.Fatalf("Expected the nodes to be subscribed when their endpoints are created. Got: %v", callbacks)
+	}
+
+	// A suspicion of another node does not crash the node
+	callbacks[0](1, false)
+	if ep1.crashed {
+		t.Errorf("Did not expect a suspicion of node 1 to crash it")
+	}
+
+	// The node detects its own crash, and the message is discarded
+	callbacks[1](1, false)
+	deliverLast(t, sch, nextEvt)
+	select {
+	case env := <-ep1.Recv():
+		t.Errorf("Expected the message to the crashed node to be discarded. Got: %v", env)
+	default:
+	}
+}
+
+func TestChannelNetworkFaults(t *testing.T) {
+	sch, nextEvt, sp := newStreamParameters()
+	sp.NetworkFaults = NewNetworkFaults(1, 0)
+	cm := NewChannelManager[int](sp)
+	ep1 := cm.Endpoint(1)
+	cm.Endpoint(0).Send(1, 1)
+	if len(sch.eventStack) != 2 {
+		t.Fatalf("Expected the message and the alternative of losing it to be added. Got: %v", sch.eventStack)
+	}
+
+	// Lose the message before it is delivered
+	deliverLast(t, sch, nextEvt)
+	deliverLast(t, sch, nextEvt)
+	select {
+	case env := <-ep1.Recv():
+		t.Errorf("Expected the message to be lost. Got: %v", env)
+	default:
+	}
+}
@@ -0,0 +1,117 @@
+package eventManager
+
+import (
+	"sync"
+	"time"
+)
+
+// A source of the current time for nodes.
+//
+// Nodes should read the time from a Clock instead of calling time.Now, since reading the real time makes events nondeterministic.
+// The Clock of a run is provided in the SimulationParameters.
+type Clock interface {
+	// Returns the current time
+	Now() time.Time
+	// Returns the time elapsed since t
+	Since(t time.Time) time.Duration
+	// Returns the duration until t
+	Until(t time.Time) time.Duration
+	// Returns true if the deadline has been reached
+	Expired(deadline time.Time) bool
+}
+
+// A Clock that reads the real time.
+//
+// Used by the Runner.
+type RealClock struct{}
+
+// Returns the current time
+func (RealClock) Now() time.Time {
+	return time.Now()
+}
+
+// Returns the time elapsed since t
+func (RealClock) Since(t time.Time) time.Duration {
+	return time.Since(t)
+}
+
+// Returns the duration until t
+func (RealClock) Until(t time.Time) time.Duration {
+	return time.Until(t)
+}
+
+// Returns true if the deadline has been reached
+func (RealClock) Expired(deadline time.Time) bool {
+	return !time.Now().Before(deadline)
+}
+
+// The time of a SimulatedClock at the start of a run
+var simulationStart = time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// A Clock driven by simulated time.
+//
+// The time only advances when timer events are executed, i.e. events created by the TimerManager and the SleepManager.
+// When a timer expires the time advances to the deadline of the timer, unless the time has already passed the deadline.
+// The time is therefore the same in all runs with the same sequence of events.
+//
+// Used by the simulator. A new SimulatedClock is created for each run.
+type SimulatedClock struct {
+	sync.Mutex
+	now time.Time
+}
+
+// Create a new SimulatedClock
+//
+// The clock starts at the same time in every run.
+func NewSimulatedClock() *SimulatedClock {
+	return &SimulatedClock{now: simulationStart}
+}
+
+// Returns the current simulated time
+func (sc *SimulatedClock) Now() time.Time {
+	sc.Lock()
+	defer sc.Unlock()
+	return sc.now
+}
+
+// Returns the simulated time elapsed since t
+func (sc *SimulatedClock) Since(t time.Time) time.Duration {
+	return sc.Now().Sub(t)
+}
+
+// Returns the simulated duration until t
+func (sc *SimulatedClock) Until(t time.Time) time.Duration {
+	return t.Sub(sc.Now())
+}
+
+// Returns true if the deadline has been reached in simulated time
+func (sc *SimulatedClock) Expired(deadline time.Time) bool {
+	return !sc.Now().Before(deadline)
+}
+
+// Advance the time to t.
+//
+// Has no effect if t is before the current time, so that time never goes backwards.
+// Called by Event Managers when a timer event is executed.
+func (sc *SimulatedClock) AdvanceTo(t time.Time) {
+	sc.Lock()
+	defer sc.Unlock()
+	if t.After(sc.now) {
+		sc.now = t
+	}
+}
+
+// Advance the clock to the deadline if the clock is simulated
+func advanceTo(clock Clock, deadline time.Time) {
+	if sc, ok := clock.(*SimulatedClock); ok {
+		sc.AdvanceTo(deadline)
+	}
+}
+
+// Returns the deadline of a timer with the provided duration, or the zero time if there is no clock
+func deadline(clock Clock, d time.Duration) time.Time {
+	if clock == nil {
+		return time.Time{}
+	}
+	return clock.Now().Add(d)
+}
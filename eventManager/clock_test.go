@@ -0,0 +1,59 @@
+package eventManager
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSimulatedClock(t *testing.T) {
+	clock := NewSimulatedClock()
+	start := clock.Now()
+	if !start.Equal(NewSimulatedClock().Now()) {
+		t.Errorf("Expected all simulated clocks to start at the same time")
+	}
+
+	clock.AdvanceTo(start.Add(time.Second))
+	if d := clock.Since(start); d != time.Second {
+		t.Errorf("Expected the clock to advance by 1s. Got: %v", d)
+	}
+	// Time does not go backwards
+	clock.AdvanceTo(start)
+	if d := clock.Since(start); d != time.Second {
+		t.Errorf("Did not expect the clock to go backwards. Got: %v", d)
+	}
+	if !clock.Expired(start.Add(time.Second)) || clock.Expired(start.Add(2*time.Second)) {
+		t.Errorf("Unexpected deadline comparison at %v", clock.Now())
+	}
+	if d := clock.Until(start.Add(3 * time.Second)); d != 2*time.Second {
+		t.Errorf("Expected 2s until the deadline. Got: %v", d)
+	}
+}
+
+func TestSimulatedClockTimers(t *testing.T) {
+	sch := NewMockScheduler()
+	clock := NewSimulatedClock()
+	start := clock.Now()
+	tm := NewTimerManager(SimulationParameters{EventAdder: removingScheduler{sch}, Clock: clock})
+
+	tm.AfterFunc(0, 5*time.Second, func() {})
+	ticker := tm.NewTicker(0, time.Second)
+
+	// The clock does not advance until a timer expires
+	if clock.Since(start) != 0 {
+		t.Errorf("Did not expect the clock to advance. Got: %v", clock.Since(start))
+	}
+	// The first tick
+	executeLast(t, sch)
+	if d := clock.Since(start); d != time.Second {
+		t.Errorf("Expected the clock to advance to the first tick. Got: %v", d)
+	}
+	if now := <-ticker.C; !now.Equal(clock.Now()) {
+		t.Errorf("Expected the tick to contain the simulated time. Got: %v", now)
+	}
+	ticker.Stop()
+
+	executeLast(t, sch)
+	if d := clock.Since(start); d != 5*time.Second {
+		t.Errorf("Expected the clock to advance to the deadline of the timer. Got: %v", d)
+	}
+}
@@ -8,3 +8,14 @@ type EventAdder interface {
 	// It must be safe to add events from different goroutines.
 	AddEvent(event.Event)
 }
+
+// A type that can remove pending Events
+//
+// Implemented by EventAdders that support cancelling events, e.g. stopping a timer.
+type EventRemover interface {
+	// Remove the pending event with the provided id.
+	//
+	// Returns true if a pending event was removed.
+	// It must be safe to remove events from different goroutines.
+	RemoveEvent(event.EventId) bool
+}
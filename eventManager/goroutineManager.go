@@ -0,0 +1,48 @@
+package eventManager
+
+import (
+	"fmt"
+	"gomc/event"
+	"runtime"
+	"sync"
+)
+
+// An Event Manager that is used to start goroutines on the nodes.
+//
+// Mimics the go statement. Each goroutine is run by an event targeting the node that started it,
+// so that the simulator decides when the goroutine runs, and the state is not collected while it is running.
+// The goroutine can block on other Event Managers, e.g. by sleeping or sending a gRPC request, in the same way as other events.
+//
+// A new GoroutineManager must be created for each run, so that the ids of the goroutines are stable across runs.
+type GoroutineManager struct {
+	sync.Mutex
+
+	ea EventAdder
+	// The number of goroutines started by each node from each location in the code
+	started map[string]int
+}
+
+// Create a GoroutineManager with the provided EventAdder
+func NewGoroutineManager(sp SimulationParameters) *GoroutineManager {
+	return &GoroutineManager{
+		ea:      sp.EventAdder,
+		started: make(map[string]int),
+	}
+}
+
+// Start a goroutine on the node with the provided id that calls f.
+//
+// Analogous to go f(). f is called while executing the event.
+// The id of the event is derived from the location in the code where Go is called, so Go should be called directly by the node.
+func (gm *GoroutineManager) Go(id int, f func()) {
+	_, file, line, _ := runtime.Caller(1)
+	caller := fmt.Sprintf("File: %v, Line: %v", file, line)
+
+	gm.Lock()
+	key := fmt.Sprint(id, caller)
+	seq := gm.started[key]
+	gm.started[key]++
+	gm.Unlock()
+
+	gm.ea.AddEvent(event.NewGoroutineEvent(id, caller, seq, f))
+}
@@ -0,0 +1,50 @@
+package eventManager
+
+import (
+	"strings"
+	"testing"
+)
+
+// Start two goroutines on node 0 from the same location, which append their index to ran
+func startGoroutines(gm *GoroutineManager, ran *[]int) {
+	for i := 0; i < 2; i++ {
+		i := i
+		gm.Go(0, func() { *ran = append(*ran, i) })
+	}
+}
+
+func TestGoroutineManager(t *testing.T) {
+	sch := NewMockScheduler()
+	gm := NewGoroutineManager(SimulationParameters{EventAdder: sch})
+
+	ran := []int{}
+	startGoroutines(gm, &ran)
+	if len(ran) != 0 {
+		t.Fatalf("Expected the goroutines to run when their events are executed. Got: %v", ran)
+	}
+	if len(sch.eventStack) != 2 {
+		t.Fatalf("Expected an event for each goroutine. Got: %v", sch.eventStack)
+	}
+	first, second := sch.eventStack[0].Id(), sch.eventStack[1].Id()
+	if first == second {
+		t.Errorf("Expected goroutines started from the same location to have different ids. Got: %v", first)
+	}
+	if !strings.HasPrefix(string(first), "Goroutine 0 ") || !strings.HasSuffix(string(first), " 0") {
+		t.Errorf("Unexpected event id: %v", first)
+	}
+
+	// The scheduler decides the order in which the goroutines run
+	executeLast(t, sch)
+	executeLast(t, sch)
+	if len(ran) != 2 || ran[0] != 1 || ran[1] != 0 {
+		t.Errorf("Expected the goroutines to run in the order their events were executed. Got: %v", ran)
+	}
+
+	// The ids are stable across runs
+	sch = NewMockScheduler()
+	gm = NewGoroutineManager(SimulationParameters{EventAdder: sch})
+	startGoroutines(gm, &ran)
+	if sch.eventStack[0].Id() != first || sch.eventStack[1].Id() != second {
+		t.Errorf("Expected the same ids in a new run. Got: %v and %v, expected: %v and %v", sch.eventStack[0].Id(), sch.eventStack[1].Id(), first, second)
+	}
+}
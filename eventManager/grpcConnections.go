@@ -0,0 +1,82 @@
+package eventManager
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// The size of the buffers of the in-memory connections
+const bufSize = 1024 * 1024
+
+// Create an in-memory listener for the gRPC server of the node.
+//
+// Connections to the listener are created using Dial.
+// Returns the same listener if it is called multiple times with the same id.
+func (gem *GrpcEventManager) Listen(id int) net.Listener {
+	gem.lock.Lock()
+	defer gem.lock.Unlock()
+	return gem.listener(id)
+}
+
+// Connect to the gRPC server of the node with id to using an in-memory connection.
+//
+// The server must listen on the listener created by Listen.
+// The connection uses insecure transport credentials unless others are provided in opts.
+// The interceptors of the GrpcEventManager identify the target of calls made on the connection as the node with id to.
+func (gem *GrpcEventManager) Dial(to int, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
+	dialOpts := append([]grpc.DialOption{
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			gem.lock.Lock()
+			lis := gem.listener(to)
+			gem.lock.Unlock()
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	}, opts...)
+	cc, err := grpc.Dial(fmt.Sprint("passthrough:///gomc-node-", to), dialOpts...)
+	if err != nil {
+		return nil, err
+	}
+	gem.Register(cc, to)
+	return cc, nil
+}
+
+// Register that the connection is connected to the gRPC server of the node with id to.
+//
+// Is used for connections that are not created by Dial, e.g. connections using the network when running the algorithm.
+// The interceptors of the GrpcEventManager can only be used on connections that are created by Dial or registered.
+func (gem *GrpcEventManager) Register(cc *grpc.ClientConn, to int) {
+	gem.lock.Lock()
+	defer gem.lock.Unlock()
+	gem.conns[cc] = to
+}
+
+// Returns the id of the node at the other end of the connection.
+//
+// Returns an error if the connection is not created by Dial or registered.
+func (gem *GrpcEventManager) target(cc *grpc.ClientConn) (int, error) {
+	gem.lock.Lock()
+	defer gem.lock.Unlock()
+	to, ok := gem.conns[cc]
+	if !ok {
+		return 0, errUnknownConnection
+	}
+	return to, nil
+}
+
+// Returns the listener of the node, creating it if it does not exist.
+//
+// Must be called while holding the lock.
+func (gem *GrpcEventManager) listener(id int) *bufconn.Listener {
+	lis, ok := gem.listeners[id]
+	if !ok {
+		lis = bufconn.Listen(bufSize)
+		gem.listeners[id] = lis
+	}
+	return lis
+}
@@ -0,0 +1,48 @@
+package eventManager
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	protoV1 "github.com/golang/protobuf/proto"
+	"google.golang.org/protobuf/proto"
+)
+
+// Creates a stable representation of a gRPC message that is used in the ids of gRPC events.
+//
+// Messages that are equal should have the same representation across runs of the same binary, so that recorded runs can be replayed.
+type EventIdFunc func(msg interface{}) string
+
+// The default EventIdFunc of the GrpcEventManager.
+//
+// Protocol buffer messages are represented by their full name and a hash of their deterministic marshaling.
+// The output of String() on a protocol buffer message is explicitly unstable, and is not used.
+// Deterministic marshaling is stable across runs of the same binary, but is not canonical,
+// so the ids may change when the protobuf library or the generated code is updated, and recorded runs may then no longer be replayed.
+// Other values are formatted using fmt.Sprint.
+func ProtoEventId(msg interface{}) string {
+	var m proto.Message
+	switch t := msg.(type) {
+	case proto.Message:
+		m = t
+	case protoV1.Message:
+		// Messages generated by older versions of protoc-gen-go
+		m = protoV1.MessageV2(t)
+	default:
+		return fmt.Sprint(msg)
+	}
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(m)
+	if err != nil {
+		return fmt.Sprint(msg)
+	}
+	sum := sha256.Sum256(data)
+	return fmt.Sprint(m.ProtoReflect().Descriptor().FullName(), ":", hex.EncodeToString(sum[:16]))
+}
+
+// Configure the function used to represent messages in the ids of gRPC events.
+//
+// Must be called before any messages are sent.
+func (gem *GrpcEventManager) SetEventIdFunc(f EventIdFunc) {
+	gem.eventId = f
+}
@@ -0,0 +1,49 @@
+package eventManager
+
+import (
+	"gomc/event"
+	"strings"
+	"testing"
+
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestProtoEventId(t *testing.T) {
+	a, _ := structpb.NewStruct(map[string]interface{}{"a": 1, "b": "x", "c": true})
+	b, _ := structpb.NewStruct(map[string]interface{}{"c": true, "b": "x", "a": 1})
+	if ProtoEventId(a) != ProtoEventId(b) {
+		t.Errorf("Expected equal messages to have the same id. Got: %v and %v", ProtoEventId(a), ProtoEventId(b))
+	}
+	c, _ := structpb.NewStruct(map[string]interface{}{"a": 2, "b": "x", "c": true})
+	if ProtoEventId(a) == ProtoEventId(c) {
+		t.Errorf("Expected different messages to have different ids. Got: %v", ProtoEventId(a))
+	}
+	if id := ProtoEventId(a); !strings.HasPrefix(id, "google.protobuf.Struct:") {
+		t.Errorf("Expected the id to contain the name of the message. Got: %v", id)
+	}
+
+	// Messages of different types with the same encoding are different
+	if ProtoEventId(&healthpb.HealthCheckRequest{}) == ProtoEventId(&healthpb.HealthCheckResponse{}) {
+		t.Errorf("Expected messages of different types to have different ids")
+	}
+
+	if id := ProtoEventId(1); id != "1" {
+		t.Errorf("Expected values that are not protocol buffers to be formatted. Got: %v", id)
+	}
+	if id := ProtoEventId(nil); id != "<nil>" {
+		t.Errorf("Expected nil to be formatted. Got: %v", id)
+	}
+}
+
+func TestSetEventIdFunc(t *testing.T) {
+	sch := NewMockScheduler()
+	gem := NewGrpcEventManager(SimulationParameters{EventAdder: sch})
+	gem.SetEventIdFunc(func(msg interface{}) string { return "payload" })
+	gem.addEvent(0, 1, &healthpb.HealthCheckRequest{Service: "foo"}, "/Check", nil, nil)
+
+	expected := event.NewGrpcEvent(0, 1, "/Check", "payload", nil)
+	if out, _ := sch.GetEvent(); out.Id() != expected.Id() {
+		t.Errorf("Expected the id to use the configured EventIdFunc. Got: %v. Expected: %v", out.Id(), expected.Id())
+	}
+}
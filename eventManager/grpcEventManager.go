@@ -4,56 +4,110 @@ import (
 	"context"
 	"errors"
 	"gomc/event"
+	"reflect"
+	"sync"
 	"time"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/test/bufconn"
 )
 
-// An Event Manager that will be used to control asynchronous messages sent using gRPC.
+// An Event Manager that will be used to control messages sent using gRPC.
 //
 // Grpc async calls are called in a separate goroutine without handling the response.
 // A context with a deadline should not be used when simulating since real time does not make sense during simulations.
 //
 // Uses a gRPC unary client interceptor to intercept and withhold messages.
-// The interceptor is created by the UnaryClientControllerInterceptor method.
-//
-// The function created by the WaitForSend method must be called after sending messages using gRPC.
+// The interceptor for async calls is created by the UnaryClientControllerInterceptor method.
+// The function created by the WaitForSend method must be called after sending async messages using gRPC.
 // This ensures that all the messages are added to the EventAdder before continuing.
+//
+// The interceptor for synchronous calls is created by the SyncUnaryClientControllerInterceptor method.
+// The request and the response of a synchronous call are delivered by separate events, and the calling node blocks until the response is delivered.
+// WaitForSend is not used with synchronous calls.
+//
+// Streams are controlled by the interceptors created by the StreamClientControllerInterceptor and StreamServerControllerInterceptor methods.
+//
+// The interceptors identify the node that a call is made to by the connection it is made on.
+// Connections are created in memory by the Dial method to servers listening on the Listen method,
+// and other connections must be registered using the Register method.
+//
+// Messages are represented in the ids of the events by an EventIdFunc, which by default hashes the deterministic marshaling of protocol buffer messages.
+//
+// If the SimulationParameters contain NetworkFaults, messages can be lost or duplicated.
+// A lost message is never sent, and the call returns an error.
 type GrpcEventManager struct {
-	addrIdMap map[string]int
-
 	ea      EventAdder
 	nextEvt func(error, int)
+	faults  *NetworkFaults
 
 	msgChan map[int]chan bool
+
+	// Represents the messages in the ids of the events
+	eventId EventIdFunc
+
+	lock        sync.Mutex
+	conns       map[*grpc.ClientConn]int
+	listeners   map[int]*bufconn.Listener
+	streams     map[string]*grpcStream
+	streamCount map[string]int
 }
 
 // Create a GrpcEventManager for use when using grpc Async calls when simulating
 // Grpc async calls are called in a separate goroutine without handling the response.
 // A context with a deadline should not be used when simulating since real time does not make sense during simulations.
-// addr is a map from address to node id, shc is the scheduler used and nextEvent is the NextEvent channel from the simulator
-func NewGrpcEventManager(addr map[string]int, sp SimulationParameters) *GrpcEventManager {
-	msgChan := make(map[int]chan bool)
-	for _, id := range addr {
-		msgChan[id] = make(chan bool)
-	}
+func NewGrpcEventManager(sp SimulationParameters) *GrpcEventManager {
 	return &GrpcEventManager{
-		addrIdMap: addr,
-		ea:        sp.EventAdder,
-		nextEvt:   sp.NextEvt,
-		msgChan:   msgChan,
+		ea:      sp.EventAdder,
+		nextEvt: sp.NextEvt,
+		faults:  sp.NetworkFaults,
+		msgChan: make(map[int]chan bool),
+
+		eventId: ProtoEventId,
+
+		conns:       make(map[*grpc.ClientConn]int),
+		listeners:   make(map[int]*bufconn.Listener),
+		streams:     make(map[string]*grpcStream),
+		streamCount: make(map[string]int),
 	}
 }
 
 // Add an grpcEvent to the scheduler.
-func (gem *GrpcEventManager) addEvent(from, to int, msg interface{}, method string, wait chan bool) {
-	gem.ea.AddEvent(event.NewGrpcEvent(
+//
+// If the network is unreliable the alternatives of losing and duplicating the message are added.
+// A value of false is sent on the wait channel if the message is lost.
+// duplicate is called if the message is duplicated.
+func (gem *GrpcEventManager) addEvent(from, to int, msg interface{}, method string, wait chan bool, duplicate func()) {
+	evt := event.NewGrpcEvent(
 		from,
 		to,
 		method,
-		msg,
+		gem.eventId(msg),
 		wait,
-	))
+	)
+	if gem.faults == nil {
+		gem.ea.AddEvent(evt)
+		return
+	}
+	gem.faults.addMessage(gem.ea, evt, func() { wait <- false }, duplicate)
+}
+
+// Send a copy of the message once the duplicate GrpcEvent is executed.
+//
+// The response of the copy is stored in a new reply, since the response of async calls are ignored.
+func (gem *GrpcEventManager) duplicate(ctx context.Context, from, to int, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) {
+	wait := make(chan bool)
+	gem.ea.AddEvent(event.NewGrpcEvent(from, to, method, gem.eventId(req), wait))
+	go func() {
+		<-wait
+		invoker(ctx, method, req, newReply(reply), cc, opts...)
+		gem.nextEvt(nil, to)
+	}()
+}
+
+// Create a new empty reply of the same type as the provided reply
+func newReply(reply interface{}) interface{} {
+	return reflect.New(reflect.TypeOf(reply).Elem()).Interface()
 }
 
 // Creates a function that wait until all messages has been processed and an event has been created for all of them.
@@ -66,11 +120,41 @@ func (gem *GrpcEventManager) addEvent(from, to int, msg interface{}, method stri
 func (gem *GrpcEventManager) WaitForSend(id int) func(int) {
 	return func(num int) {
 		for i := 0; i < num; i++ {
-			<-gem.msgChan[id]
+			<-gem.sendConfirmation(id)
 		}
 	}
 }
 
+// Signal to WaitForSend that a message sent by the node has been processed.
+//
+// Panics if WaitForSend is not called within 10 seconds.
+func (gem *GrpcEventManager) confirmSend(id int) {
+	select {
+	case gem.sendConfirmation(id) <- true:
+	case <-time.After(10 * time.Second):
+		// The wait for send method has not been called. Panic to inform the user
+		panic(errors.New("grpcEventManager: timed out while confirming that message has been processed. grpcEventManager.WaitForSend must be called after sending messages to ensure that the message is properly handled."))
+	}
+}
+
+// Returns the channel used to confirm that messages sent by the node have been processed
+func (gem *GrpcEventManager) sendConfirmation(id int) chan bool {
+	gem.lock.Lock()
+	defer gem.lock.Unlock()
+	c, ok := gem.msgChan[id]
+	if !ok {
+		c = make(chan bool)
+		gem.msgChan[id] = c
+	}
+	return c
+}
+
+// Returned by calls whose message was lost by the network
+var errMessageLost = errors.New("grpcEventManager: the message was lost by the network")
+
+// Returned by calls made on connections that are not created by Dial or registered using Register
+var errUnknownConnection = errors.New("grpcEventManager: the target of the connection is unknown. The connection must be created by Dial or registered using Register")
+
 // Create a UnaryClientInterceptor that is used to control the message flow of grpc events.
 // The id is the id of the client node sending the requests
 //
@@ -78,24 +162,29 @@ func (gem *GrpcEventManager) WaitForSend(id int) func(int) {
 // After the grpcRequest event is executed and an (empty) response has been received it signals that the event is completed and that the next event can be executed
 func (gem *GrpcEventManager) UnaryClientControllerInterceptor(id int) grpc.UnaryClientInterceptor {
 	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
-		target := gem.addrIdMap[cc.Target()] // HACK: cc.Target() is an experimental API
+		target, err := gem.target(cc)
+		if err != nil {
+			// No event is created, but the message must be confirmed so that WaitForSend returns
+			gem.confirmSend(id)
+			return err
+		}
 
 		// Create a request event
 		wait := make(chan bool)
-		gem.addEvent(id, target, req, method, wait)
+		gem.addEvent(id, target, req, method, wait, func() {
+			gem.duplicate(ctx, id, target, method, req, reply, cc, invoker, opts...)
+		})
 
 		// Signal that an event has been created for the event
+		gem.confirmSend(id)
 
-		select {
-		case gem.msgChan[id] <- true:
-		case <-time.After(10 * time.Second):
-			// The wait for send method has not been called. Panic to i
-			panic(errors.New("grpcEventManager: timed out while confirming that message has been processed. grpcEventManager.WaitForSend must be called after sending messages to ensure that the message is properly handled."))
-		}
 		// Wait until the event has been executed
-		<-wait
+		if send := <-wait; !send {
+			// The message was lost. The event signals that it is completed
+			return errMessageLost
+		}
 
-		err := invoker(ctx, method, req, reply, cc, opts...)
+		err = invoker(ctx, method, req, reply, cc, opts...)
 
 		// Signal that the message event has been completely processed by the server
 		gem.nextEvt(nil, target)
@@ -103,3 +192,41 @@ func (gem *GrpcEventManager) UnaryClientControllerInterceptor(id int) grpc.Unary
 		return err
 	}
 }
+
+// Create a UnaryClientInterceptor that is used to control the message flow of synchronous grpc calls.
+// The id is the id of the client node making the calls
+//
+// It creates a GrpcRequestEvent and blocks the calling node until the response of the call has been delivered.
+// When the GrpcRequestEvent is executed the request is handled by the server, and a GrpcResponseEvent delivering the response is added.
+// Both the request and the response can be lost by the network or by a partition.
+// If either is lost the call returns an error, which is delivered by a GrpcResponseEvent from the calling node to itself.
+// The call must be made while the node is executing an event, and not in a separate goroutine.
+func (gem *GrpcEventManager) SyncUnaryClientControllerInterceptor(id int) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		target, err := gem.target(cc)
+		if err != nil {
+			return err
+		}
+
+		return syncCall[error]{
+			ea:      gem.ea,
+			nextEvt: gem.nextEvt,
+			faults:  gem.faults,
+			from:    id,
+			to:      target,
+			request: func(deliver func(), drop func()) droppableMessage {
+				return event.NewGrpcRequestEvent(id, target, method, gem.eventId(req), deliver, drop)
+			},
+			response: func(from int, err error, respond func(), drop func()) droppableMessage {
+				result := gem.eventId(reply)
+				if err != nil {
+					result = err.Error()
+				}
+				return event.NewGrpcResponseEvent(from, id, method, result, respond, drop)
+			},
+			send:   func() error { return invoker(ctx, method, req, reply, cc, opts...) },
+			resend: func() { invoker(ctx, method, req, newReply(reply), cc, opts...) },
+			lost:   errMessageLost,
+		}.call()
+	}
+}
@@ -0,0 +1,214 @@
+package eventManager
+
+import (
+	"context"
+	"errors"
+	"gomc/event"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+type healthServer struct {
+	healthpb.UnimplementedHealthServer
+	calls int
+}
+
+func (hs *healthServer) Check(ctx context.Context, in *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+	hs.calls++
+	return &healthpb.HealthCheckResponse{Status: healthpb.HealthCheckResponse_SERVING}, nil
+}
+
+type checkResult struct {
+	resp *healthpb.HealthCheckResponse
+	err  error
+}
+
+// Start a health server on node 1 and make a synchronous call to it from node 0.
+//
+// Returns when the call has been intercepted and node 0 is blocked waiting for the response.
+func startSyncCall(t *testing.T, sp SimulationParameters, nextEvt chan error) (*healthServer, chan checkResult) {
+	t.Helper()
+	gem := NewGrpcEventManager(sp)
+	hs := &healthServer{}
+	srv := grpc.NewServer()
+	healthpb.RegisterHealthServer(srv, hs)
+	go srv.Serve(gem.Listen(1))
+	t.Cleanup(srv.Stop)
+
+	conn, err := gem.Dial(1, grpc.WithUnaryInterceptor(gem.SyncUnaryClientControllerInterceptor(0)))
+	if err != nil {
+		t.Fatalf("Unexpected error dialing the server: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	results := make(chan checkResult)
+	go func() {
+		resp, err := healthpb.NewHealthClient(conn).Check(context.Background(), &healthpb.HealthCheckRequest{})
+		results <- checkResult{resp, err}
+	}()
+	<-nextEvt
+	return hs, results
+}
+
+// Execute the only pending event, which must be of type E
+func executePending[E event.Event](t *testing.T, sch *MockScheduler, errorChan chan error) {
+	t.Helper()
+	if len(sch.eventStack) != 1 {
+		t.Fatalf("Expected 1 pending event. Got: %v", sch.eventStack)
+	}
+	evt, _ := sch.GetEvent()
+	if _, ok := evt.(E); !ok {
+		t.Fatalf("Expected an event of type %T. Got: %v", *new(E), evt)
+	}
+	go evt.Execute(nil, errorChan)
+}
+
+func TestGrpcSyncCall(t *testing.T) {
+	sch := NewMockScheduler()
+	nextEvt := make(chan error)
+	hs, results := startSyncCall(t, SimulationParameters{
+		EventAdder: sch,
+		NextEvt:    func(err error, _ int) { nextEvt <- err },
+	}, nextEvt)
+
+	if hs.calls != 0 {
+		t.Errorf("Did not expect the request to arrive before the request event is executed")
+	}
+	executePending[event.GrpcRequestEvent](t, sch, nextEvt)
+	if err := <-nextEvt; err != nil {
+		t.Fatalf("Unexpected error executing the request: %v", err)
+	}
+	if hs.calls != 1 {
+		t.Errorf("Expected the request to be handled by the server. Got %v calls", hs.calls)
+	}
+	select {
+	case res := <-results:
+		t.Fatalf("Did not expect the call to return before the response event is executed. Got: %v", res)
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	executePending[event.GrpcResponseEvent](t, sch, nextEvt)
+	res := <-results
+	if res.err != nil {
+		t.Fatalf("Unexpected error from the call: %v", res.err)
+	}
+	if res.resp.GetStatus() != healthpb.HealthCheckResponse_SERVING {
+		t.Errorf("Expected the response of the server. Got: %v", res.resp)
+	}
+}
+
+func TestGrpcSyncCallLost(t *testing.T) {
+	sch := NewMockScheduler()
+	nextEvt := make(chan error)
+	hs, results := startSyncCall(t, SimulationParameters{
+		EventAdder:    sch,
+		NextEvt:       func(err error, _ int) { nextEvt <- err },
+		NetworkFaults: NewNetworkFaults(1, 0),
+	}, nextEvt)
+
+	executeWithPrefix(t, sch, "Drop", nil)
+	// The request can no longer be delivered
+	executeWithPrefix(t, sch, "GrpcRequest", nil)
+	if hs.calls != 0 {
+		t.Errorf("Did not expect the lost request to be handled by the server")
+	}
+
+	executePending[event.GrpcResponseEvent](t, sch, nextEvt)
+	if res := <-results; !errors.Is(res.err, errMessageLost) {
+		t.Errorf("Expected the call to return an error when the request is lost. Got: %v", res.err)
+	}
+}
+
+func TestGrpcSyncCallResponseLost(t *testing.T) {
+	sch := NewMockScheduler()
+	nextEvt := make(chan error)
+	hs, results := startSyncCall(t, SimulationParameters{
+		EventAdder:    removingScheduler{sch},
+		NextEvt:       func(err error, _ int) { nextEvt <- err },
+		NetworkFaults: NewNetworkFaults(1, 0),
+	}, nextEvt)
+
+	executeWithPrefix(t, sch, "GrpcRequest", nil)
+	if hs.calls != 1 {
+		t.Errorf("Expected the request to be handled by the server. Got %v calls", hs.calls)
+	}
+	for _, evt := range sch.eventStack {
+		if strings.HasPrefix(string(evt.Id()), "GrpcResponse") {
+			if _, ok := evt.(event.MessageEvent); !ok {
+				t.Errorf("Expected the response to be a MessageEvent. Got: %v", evt)
+			}
+		}
+	}
+
+	executeWithPrefix(t, sch, "Drop GrpcResponse", nil)
+	// The delivery of the lost response is removed
+	if n := countWithPrefix(sch, "GrpcResponse1"); n != 0 {
+		t.Errorf("Expected the delivery of the lost response to be removed. Got: %v", sch.eventStack)
+	}
+
+	// The caller is informed that the message was lost
+	executePending[event.GrpcResponseEvent](t, sch, nextEvt)
+	if res := <-results; !errors.Is(res.err, errMessageLost) {
+		t.Errorf("Expected the call to return an error when the response is lost. Got: %v", res.err)
+	}
+}
+
+func TestGrpcUnknownConnection(t *testing.T) {
+	gem := NewGrpcEventManager(SimulationParameters{EventAdder: NewMockScheduler()})
+	srv := grpc.NewServer()
+	healthpb.RegisterHealthServer(srv, &healthServer{})
+	go srv.Serve(gem.Listen(1))
+	t.Cleanup(srv.Stop)
+
+	// A connection to the server that is not created by Dial or registered
+	conn, err := grpc.Dial("passthrough:///unknown",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return gem.Listen(1).(*bufconn.Listener).DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithUnaryInterceptor(gem.SyncUnaryClientControllerInterceptor(0)),
+	)
+	if err != nil {
+		t.Fatalf("Unexpected error dialing the server: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	_, err = healthpb.NewHealthClient(conn).Check(context.Background(), &healthpb.HealthCheckRequest{})
+	if !errors.Is(err, errUnknownConnection) {
+		t.Errorf("Expected an error when the target of the connection is unknown. Got: %v", err)
+	}
+
+	gem.Register(conn, 1)
+	if to, err := gem.target(conn); err != nil || to != 1 {
+		t.Errorf("Expected the registered connection to target node 1. Got: %v, %v", to, err)
+	}
+}
+
+func TestGrpcSyncCallResponseNotDuplicated(t *testing.T) {
+	sch := NewMockScheduler()
+	nextEvt := make(chan error)
+	hs, _ := startSyncCall(t, SimulationParameters{
+		EventAdder:    removingScheduler{sch},
+		NextEvt:       func(err error, _ int) { nextEvt <- err },
+		NetworkFaults: NewNetworkFaults(0, 1),
+	}, nextEvt)
+
+	if countWithPrefix(sch, "Duplicate GrpcRequest") != 1 {
+		t.Fatalf("Expected the request to be duplicable. Got: %v", sch.eventStack)
+	}
+	executeWithPrefix(t, sch, "GrpcRequest", nil)
+	if hs.calls != 1 {
+		t.Errorf("Expected the request to be handled by the server. Got %v calls", hs.calls)
+	}
+	// A duplicated response would have no effect, so it does not use the budget of duplicates
+	if countWithPrefix(sch, "Duplicate GrpcResponse") != 0 {
+		t.Errorf("Did not expect the response to be duplicable. Got: %v", sch.eventStack)
+	}
+}
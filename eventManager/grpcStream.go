@@ -0,0 +1,375 @@
+package eventManager
+
+import (
+	"context"
+	"fmt"
+	"gomc/event"
+	"io"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// The metadata key used to identify a controlled stream on the server
+const streamKey = "gomc-stream"
+
+// Create a StreamClientInterceptor that is used to control the message flow of grpc streams.
+// The id is the id of the client node opening the streams
+//
+// Opening the stream, each message sent on the stream and closing the stream using CloseSend are represented by GrpcStreamEvents.
+// The operations are withheld until their events are executed, and the operations in each direction of the stream are delivered in order.
+// The server must use the interceptor created by StreamServerControllerInterceptor.
+//
+// Messages sent by the server must be received in a separate goroutine that is blocked in RecvMsg when the messages are delivered, e.g. a receive loop.
+// The event delivering a message is completed when the goroutine calls RecvMsg again, or when the stream ends.
+// Cancelling the context of the stream is not controlled, and streams should be ended by the client using CloseSend.
+func (gem *GrpcEventManager) StreamClientControllerInterceptor(id int) grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		target, err := gem.target(cc)
+		if err != nil {
+			return nil, err
+		}
+		s := gem.newStream(ctx, id, target, method, desc)
+		s.enqueue(&s.toServer, "Open", nil, func() error {
+			s.cs, s.err = streamer(metadata.AppendToOutgoingContext(ctx, streamKey, s.key), desc, cc, method, opts...)
+			close(s.opened)
+			return s.err
+		})
+		return &clientStream{s: s}, nil
+	}
+}
+
+// Create a StreamServerInterceptor that is used to control the message flow of grpc streams opened by clients using the interceptor created by StreamClientControllerInterceptor.
+//
+// Each message sent by the server is represented by a GrpcStreamEvent.
+// When the handler returns the stream is closed with the status of the handler once the closing GrpcStreamEvent is executed.
+// A handler returning an error resets the stream.
+// The event delivering an operation from the client is completed when the handler calls RecvMsg again, or when the handler returns.
+//
+// Streams that are not opened by a controlled client are not controlled.
+func (gem *GrpcEventManager) StreamServerControllerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		s := gem.stream(ss.Context())
+		if s == nil {
+			return handler(srv, ss)
+		}
+		wrapped := &serverStream{ServerStream: ss, s: s, serverStreams: info.IsServerStream}
+		err := handler(srv, wrapped)
+
+		var result interface{} = wrapped.response
+		if err != nil {
+			result = err
+		}
+		released := make(chan struct{})
+		s.enqueue(&s.toClient, "Close", result, func() error {
+			if err == nil && wrapped.response != nil {
+				ss.SendMsg(wrapped.response)
+			}
+			s.Lock()
+			s.closed = true
+			s.Unlock()
+			close(released)
+			return nil
+		})
+		s.stopReceive(&s.toServer)
+		gem.removeStream(s.key)
+
+		// Hold the status of the stream until the closing event is executed
+		select {
+		case <-released:
+		case <-ss.Context().Done():
+		}
+		return err
+	}
+}
+
+// Create a new controlled stream from the client to the target
+func (gem *GrpcEventManager) newStream(ctx context.Context, client, server int, method string, desc *grpc.StreamDesc) *grpcStream {
+	gem.lock.Lock()
+	defer gem.lock.Unlock()
+	prefix := fmt.Sprint(client, "-", server, " ", method)
+	num := gem.streamCount[prefix]
+	gem.streamCount[prefix]++
+	s := &grpcStream{
+		gem:    gem,
+		key:    fmt.Sprint(prefix, " ", num),
+		method: method,
+		num:    num,
+		ctx:    ctx,
+		desc:   desc,
+		opened: make(chan struct{}),
+		// The server starts receiving when the stream is opened
+		toServer: streamDirection{from: client, to: server, waiting: true},
+		toClient: streamDirection{from: server, to: client},
+	}
+	gem.streams[s.key] = s
+	return s
+}
+
+// Returns the controlled stream that the context belongs to, or nil if the stream is not controlled
+func (gem *GrpcEventManager) stream(ctx context.Context) *grpcStream {
+	md, _ := metadata.FromIncomingContext(ctx)
+	keys := md.Get(streamKey)
+	if len(keys) == 0 {
+		return nil
+	}
+	gem.lock.Lock()
+	defer gem.lock.Unlock()
+	return gem.streams[keys[0]]
+}
+
+func (gem *GrpcEventManager) removeStream(key string) {
+	gem.lock.Lock()
+	defer gem.lock.Unlock()
+	delete(gem.streams, key)
+}
+
+// An operation on a stream that is withheld until its event is executed
+type streamItem struct {
+	kind    string
+	seq     int
+	payload string
+	action  func() error
+}
+
+// One direction of a stream
+type streamDirection struct {
+	from int
+	to   int
+
+	// The operations that have not been delivered. Only the first operation has an event.
+	queue []streamItem
+	seq   int
+
+	// True if the receiver is waiting in RecvMsg and no delivered operation is available
+	waiting bool
+	// True if the receiver must signal that the event delivering the last operation is completed
+	pending bool
+	// True if the receiver does not receive more operations
+	done bool
+	// The number of operations that have been delivered but not received
+	available int
+}
+
+// A gRPC stream controlled by the GrpcEventManager
+type grpcStream struct {
+	sync.Mutex
+	gem *GrpcEventManager
+
+	key    string
+	method string
+	num    int
+	ctx    context.Context
+	desc   *grpc.StreamDesc
+
+	toServer streamDirection
+	toClient streamDirection
+
+	// The stream of the client. Is set when the stream is opened.
+	cs     grpc.ClientStream
+	err    error
+	opened chan struct{}
+	// True when the server has closed the stream
+	closed bool
+}
+
+// Returns the stream of the client once the stream has been opened
+func (s *grpcStream) clientStream() (grpc.ClientStream, error) {
+	<-s.opened
+	return s.cs, s.err
+}
+
+// Add an operation to the direction of the stream.
+//
+// An event is added for the operation if it is the only operation that has not been delivered.
+func (s *grpcStream) enqueue(d *streamDirection, kind string, msg interface{}, action func() error) {
+	payload := s.gem.eventId(msg)
+	s.Lock()
+	defer s.Unlock()
+	item := streamItem{kind: kind, seq: d.seq, payload: payload, action: action}
+	d.seq++
+	d.queue = append(d.queue, item)
+	if len(d.queue) == 1 {
+		s.gem.ea.AddEvent(s.event(d, item))
+	}
+}
+
+func (s *grpcStream) event(d *streamDirection, item streamItem) event.GrpcStreamEvent {
+	return event.NewGrpcStreamEvent(d.from, d.to, s.method, s.num, item.kind, item.seq, item.payload, func() bool {
+		return s.deliver(d)
+	})
+}
+
+// Deliver the first operation of the direction and add an event for the next operation.
+//
+// Returns true if the receiver is not waiting for the operation, and the event is completed.
+// Otherwise the receiver signals when it has handled the operation.
+func (s *grpcStream) deliver(d *streamDirection) bool {
+	s.Lock()
+	item := d.queue[0]
+	d.queue = d.queue[1:]
+	if len(d.queue) > 0 {
+		s.gem.ea.AddEvent(s.event(d, d.queue[0]))
+	}
+	expect := d.waiting && !d.done
+	if expect {
+		d.waiting = false
+		d.pending = true
+	} else if !d.done {
+		d.available++
+	}
+	s.Unlock()
+
+	if err := item.action(); err != nil {
+		// The receiver does not receive the operation
+		s.Lock()
+		defer s.Unlock()
+		if expect {
+			d.pending = false
+			d.waiting = true
+		} else if !d.done {
+			d.available--
+		}
+		return true
+	}
+	return !expect
+}
+
+// Called by the receiver before it waits for the next operation.
+//
+// Signals that the event delivering the previous operation is completed.
+func (s *grpcStream) startReceive(d *streamDirection) {
+	s.Lock()
+	signal := d.pending
+	d.pending = false
+	if d.available > 0 {
+		d.available--
+	} else {
+		d.waiting = true
+	}
+	s.Unlock()
+	if signal {
+		s.gem.nextEvt(nil, d.to)
+	}
+}
+
+// Called by the receiver when it does not receive more operations.
+//
+// Signals that the event delivering the previous operation is completed.
+func (s *grpcStream) stopReceive(d *streamDirection) {
+	s.Lock()
+	signal := d.pending
+	d.pending = false
+	d.waiting = false
+	d.done = true
+	s.Unlock()
+	if signal {
+		s.gem.nextEvt(nil, d.to)
+	}
+}
+
+// The client side of a controlled stream
+type clientStream struct {
+	s *grpcStream
+}
+
+func (cs *clientStream) Header() (metadata.MD, error) {
+	stream, err := cs.s.clientStream()
+	if err != nil {
+		return nil, err
+	}
+	return stream.Header()
+}
+
+func (cs *clientStream) Trailer() metadata.MD {
+	select {
+	case <-cs.s.opened:
+		if cs.s.err == nil {
+			return cs.s.cs.Trailer()
+		}
+	default:
+	}
+	return nil
+}
+
+// Close the sending direction of the stream once the closing event is executed
+func (cs *clientStream) CloseSend() error {
+	s := cs.s
+	s.enqueue(&s.toServer, "Close", nil, func() error {
+		stream, err := s.clientStream()
+		if err != nil {
+			return err
+		}
+		return stream.CloseSend()
+	})
+	return nil
+}
+
+func (cs *clientStream) Context() context.Context {
+	return cs.s.ctx
+}
+
+// Send the message once its event is executed.
+//
+// Returns io.EOF if the server has closed the stream.
+func (cs *clientStream) SendMsg(m interface{}) error {
+	s := cs.s
+	s.Lock()
+	closed := s.closed
+	s.Unlock()
+	if closed {
+		return io.EOF
+	}
+	s.enqueue(&s.toServer, "Send", m, func() error {
+		stream, err := s.clientStream()
+		if err != nil {
+			return err
+		}
+		return stream.SendMsg(m)
+	})
+	return nil
+}
+
+func (cs *clientStream) RecvMsg(m interface{}) error {
+	s := cs.s
+	s.startReceive(&s.toClient)
+	stream, err := s.clientStream()
+	if err == nil {
+		err = stream.RecvMsg(m)
+	}
+	if err != nil || !s.desc.ServerStreams {
+		// The stream has ended
+		s.stopReceive(&s.toClient)
+	}
+	return err
+}
+
+// The server side of a controlled stream
+type serverStream struct {
+	grpc.ServerStream
+	s *grpcStream
+
+	serverStreams bool
+	// The response of a stream where the server does not stream, which is sent when the stream is closed
+	response interface{}
+}
+
+// Send the message once its event is executed.
+//
+// If the server does not stream the message is sent when the stream is closed.
+func (ss *serverStream) SendMsg(m interface{}) error {
+	if !ss.serverStreams {
+		ss.response = m
+		return nil
+	}
+	ss.s.enqueue(&ss.s.toClient, "Send", m, func() error {
+		return ss.ServerStream.SendMsg(m)
+	})
+	return nil
+}
+
+func (ss *serverStream) RecvMsg(m interface{}) error {
+	ss.s.startReceive(&ss.s.toServer)
+	return ss.ServerStream.RecvMsg(m)
+}
@@ -0,0 +1,218 @@
+package eventManager
+
+import (
+	"context"
+	"gomc/event"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/exp/slices"
+	"google.golang.org/grpc"
+	testpb "google.golang.org/grpc/interop/grpc_testing"
+)
+
+type streamServer struct {
+	testpb.UnimplementedTestServiceServer
+}
+
+func (ss *streamServer) StreamingOutputCall(req *testpb.StreamingOutputCallRequest, stream testpb.TestService_StreamingOutputCallServer) error {
+	for _, p := range req.GetResponseParameters() {
+		stream.Send(&testpb.StreamingOutputCallResponse{Payload: &testpb.Payload{Body: make([]byte, p.GetSize())}})
+	}
+	return nil
+}
+
+func (ss *streamServer) StreamingInputCall(stream testpb.TestService_StreamingInputCallServer) error {
+	var size int32
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			return stream.SendAndClose(&testpb.StreamingInputCallResponse{AggregatedPayloadSize: size})
+		}
+		if err != nil {
+			return err
+		}
+		size += int32(len(req.GetPayload().GetBody()))
+	}
+}
+
+func (ss *streamServer) FullDuplexCall(stream testpb.TestService_FullDuplexCallServer) error {
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		for _, p := range req.GetResponseParameters() {
+			stream.Send(&testpb.StreamingOutputCallResponse{Payload: &testpb.Payload{Body: make([]byte, p.GetSize())}})
+		}
+	}
+}
+
+// Start a test server on node 1 and create a client on node 0 using controlled streams
+func startStreamServer(t *testing.T, sp SimulationParameters) testpb.TestServiceClient {
+	t.Helper()
+	gem := NewGrpcEventManager(sp)
+	srv := grpc.NewServer(grpc.StreamInterceptor(gem.StreamServerControllerInterceptor()))
+	testpb.RegisterTestServiceServer(srv, &streamServer{})
+	go srv.Serve(gem.Listen(1))
+	t.Cleanup(srv.Stop)
+
+	conn, err := gem.Dial(1, grpc.WithStreamInterceptor(gem.StreamClientControllerInterceptor(0)))
+	if err != nil {
+		t.Fatalf("Unexpected error dialing the server: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return testpb.NewTestServiceClient(conn)
+}
+
+// Execute the pending events in the order they were added until no events are pending.
+//
+// Returns the ids of the executed events.
+func executeStreamEvents(t *testing.T, sch *MockScheduler, nextEvt chan error) []event.EventId {
+	t.Helper()
+	ids := []event.EventId{}
+	for len(sch.eventStack) > 0 {
+		evt := sch.eventStack[0]
+		sch.eventStack = sch.eventStack[1:]
+		ids = append(ids, evt.Id())
+		go evt.Execute(nil, nextEvt)
+		select {
+		case err := <-nextEvt:
+			if err != nil {
+				t.Fatalf("Unexpected error executing %v: %v", evt, err)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("Timed out executing %v. Executed: %v", evt, ids)
+		}
+	}
+	return ids
+}
+
+func newStreamParameters() (*MockScheduler, chan error, SimulationParameters) {
+	sch := NewMockScheduler()
+	nextEvt := make(chan error)
+	return sch, nextEvt, SimulationParameters{
+		EventAdder: sch,
+		NextEvt:    func(err error, _ int) { nextEvt <- err },
+	}
+}
+
+// Open a server stream and receive the responses
+func runServerStream(t *testing.T) ([]event.EventId, []int) {
+	sch, nextEvt, sp := newStreamParameters()
+	client := startStreamServer(t, sp)
+
+	opened := make(chan testpb.TestService_StreamingOutputCallClient)
+	received := make(chan []int)
+	go func() {
+		stream, _ := client.StreamingOutputCall(context.Background(), &testpb.StreamingOutputCallRequest{
+			ResponseParameters: []*testpb.ResponseParameters{{Size: 1}, {Size: 2}},
+		})
+		opened <- stream
+		sizes := []int{}
+		for {
+			resp, err := stream.Recv()
+			if err != nil {
+				break
+			}
+			sizes = append(sizes, len(resp.GetPayload().GetBody()))
+		}
+		received <- sizes
+	}()
+	<-opened
+	ids := executeStreamEvents(t, sch, nextEvt)
+	return ids, <-received
+}
+
+func TestGrpcServerStream(t *testing.T) {
+	ids, sizes := runServerStream(t)
+	if !slices.Equal(sizes, []int{1, 2}) {
+		t.Errorf("Expected to receive both responses. Got: %v", sizes)
+	}
+	kinds := []string{"0-1 Open", "0-1 Send", "0-1 Close", "1-0 Send", "1-0 Send", "1-0 Close"}
+	for _, kind := range kinds {
+		found := slices.IndexFunc(ids, func(id event.EventId) bool {
+			return strings.HasPrefix(string(id), "GrpcStream "+strings.Split(kind, " ")[0]) && strings.Contains(string(id), strings.Split(kind, " ")[1])
+		})
+		if found == -1 {
+			t.Errorf("Expected an event for %v. Got: %v", kind, ids)
+		}
+	}
+	if len(ids) != len(kinds) {
+		t.Errorf("Expected %v events. Got: %v", len(kinds), ids)
+	}
+
+	// The ids of the events are stable across runs
+	if again, _ := runServerStream(t); !slices.Equal(ids, again) {
+		t.Errorf("Expected the same events in the same order. Got: %v. Expected: %v", again, ids)
+	}
+}
+
+func TestGrpcClientStream(t *testing.T) {
+	sch, nextEvt, sp := newStreamParameters()
+	client := startStreamServer(t, sp)
+
+	opened := make(chan bool)
+	result := make(chan int32)
+	go func() {
+		stream, _ := client.StreamingInputCall(context.Background())
+		stream.Send(&testpb.StreamingInputCallRequest{Payload: &testpb.Payload{Body: make([]byte, 1)}})
+		stream.Send(&testpb.StreamingInputCallRequest{Payload: &testpb.Payload{Body: make([]byte, 2)}})
+		// Equivalent to CloseAndRecv
+		stream.CloseSend()
+		opened <- true
+		resp := &testpb.StreamingInputCallResponse{}
+		if err := stream.RecvMsg(resp); err != nil {
+			result <- -1
+			return
+		}
+		result <- resp.GetAggregatedPayloadSize()
+	}()
+	<-opened
+	ids := executeStreamEvents(t, sch, nextEvt)
+	if size := <-result; size != 3 {
+		t.Errorf("Expected the aggregated size of the messages. Got: %v", size)
+	}
+	// Open, two messages and close from the client, and close with the response from the server
+	if len(ids) != 5 {
+		t.Errorf("Expected 5 events. Got: %v", ids)
+	}
+}
+
+func TestGrpcBidirectionalStream(t *testing.T) {
+	sch, nextEvt, sp := newStreamParameters()
+	client := startStreamServer(t, sp)
+
+	opened := make(chan bool)
+	received := make(chan []int)
+	go func() {
+		stream, _ := client.FullDuplexCall(context.Background())
+		stream.Send(&testpb.StreamingOutputCallRequest{ResponseParameters: []*testpb.ResponseParameters{{Size: 1}}})
+		stream.Send(&testpb.StreamingOutputCallRequest{ResponseParameters: []*testpb.ResponseParameters{{Size: 2}, {Size: 3}}})
+		stream.CloseSend()
+		opened <- true
+		sizes := []int{}
+		for {
+			resp, err := stream.Recv()
+			if err != nil {
+				break
+			}
+			sizes = append(sizes, len(resp.GetPayload().GetBody()))
+		}
+		received <- sizes
+	}()
+	<-opened
+	ids := executeStreamEvents(t, sch, nextEvt)
+	if sizes := <-received; !slices.Equal(sizes, []int{1, 2, 3}) {
+		t.Errorf("Expected to receive the responses in order. Got: %v", sizes)
+	}
+	// Open, two messages and close from the client, and three messages and close from the server
+	if len(ids) != 8 {
+		t.Errorf("Expected 8 events. Got: %v", ids)
+	}
+}
@@ -0,0 +1,201 @@
+package eventManager
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"gomc/event"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// An Event Manager that will be used to control requests sent using net/http.
+//
+// Servers serve on the in-memory listener created by Listen, and clients send requests using the RoundTripper created by Transport.
+// The host of the URL of a request identifies the server, e.g. http://node-1/path is served by the node with id 1. See the URL method.
+//
+// The request and the response are delivered by separate events, and the calling node blocks until the response is delivered.
+// Requests must be made while the node is executing an event, and not in a separate goroutine.
+// The ids of the events are derived from the method, the path and a hash of the body.
+// The response is withheld until it has been completely written, so handlers should not change the state of the node after they have written the response.
+//
+// If the SimulationParameters contain NetworkFaults, requests and responses can be lost or duplicated.
+// Requests and responses are also lost if the network is partitioned.
+// A lost request is never sent, and if either the request or the response is lost the request returns an error.
+type HttpEventManager struct {
+	ea      EventAdder
+	nextEvt func(error, int)
+	faults  *NetworkFaults
+
+	lock      sync.Mutex
+	listeners map[int]*bufconn.Listener
+	// Sends the requests to the in-memory listeners
+	transport *http.Transport
+}
+
+// Create a new HttpEventManager
+func NewHttpEventManager(sp SimulationParameters) *HttpEventManager {
+	hem := &HttpEventManager{
+		ea:      sp.EventAdder,
+		nextEvt: sp.NextEvt,
+		faults:  sp.NetworkFaults,
+
+		listeners: make(map[int]*bufconn.Listener),
+	}
+	hem.transport = &http.Transport{
+		DialContext: func(ctx context.Context, _, addr string) (net.Conn, error) {
+			host, _, err := net.SplitHostPort(addr)
+			if err != nil {
+				return nil, err
+			}
+			id, err := nodeId(host)
+			if err != nil {
+				return nil, err
+			}
+			return hem.Listen(id).(*bufconn.Listener).DialContext(ctx)
+		},
+	}
+	return hem
+}
+
+// Returned by requests whose request or response was lost by the network
+var errRequestLost = errors.New("httpEventManager: the request or the response was lost by the network")
+
+// Returns the id of the node with the provided host name
+func nodeId(host string) (int, error) {
+	if !strings.HasPrefix(host, "node-") {
+		return 0, fmt.Errorf("httpEventManager: unknown host %q. The host must be the address of a node, e.g. node-1", host)
+	}
+	id, err := strconv.Atoi(strings.TrimPrefix(host, "node-"))
+	if err != nil {
+		return 0, fmt.Errorf("httpEventManager: unknown host %q. The host must be the address of a node, e.g. node-1", host)
+	}
+	return id, nil
+}
+
+// Returns the URL of the server of the node, e.g. http://node-1
+func (hem *HttpEventManager) URL(id int) string {
+	return fmt.Sprint("http://", NodeAddr(id))
+}
+
+// Create an in-memory listener for the HTTP server of the node.
+//
+// Returns the same listener if it is called multiple times with the same id.
+func (hem *HttpEventManager) Listen(id int) net.Listener {
+	hem.lock.Lock()
+	defer hem.lock.Unlock()
+	lis, ok := hem.listeners[id]
+	if !ok {
+		lis = bufconn.Listen(bufSize)
+		hem.listeners[id] = lis
+	}
+	return lis
+}
+
+// Close the idle connections to the servers.
+//
+// Should be called by the stop function when the run ends, e.g. by calling CloseIdleConnections on the clients.
+func (hem *HttpEventManager) Close() {
+	hem.transport.CloseIdleConnections()
+}
+
+// Create the RoundTripper used by the node with the provided id to send requests.
+//
+// e.g. client := &http.Client{Transport: hem.Transport(id)}
+func (hem *HttpEventManager) Transport(id int) http.RoundTripper {
+	return &httpRoundTripper{hem: hem, id: id}
+}
+
+// The result of a request
+type httpResult struct {
+	resp *http.Response
+	body []byte
+	err  error
+}
+
+// A RoundTripper that withholds requests and responses until their events are executed
+type httpRoundTripper struct {
+	hem *HttpEventManager
+	id  int
+}
+
+// Close the idle connections to the servers when the CloseIdleConnections method of the client is called
+func (rt *httpRoundTripper) CloseIdleConnections() {
+	rt.hem.Close()
+}
+
+// Send the request once the HttpRequestEvent is executed and block until the HttpResponseEvent is executed.
+func (rt *httpRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	hem := rt.hem
+	var body []byte
+	if req.Body != nil {
+		data, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		body = data
+	}
+	to, err := nodeId(req.URL.Hostname())
+	if err != nil {
+		return nil, err
+	}
+	path := req.URL.RequestURI()
+
+	res := syncCall[httpResult]{
+		ea:      hem.ea,
+		nextEvt: hem.nextEvt,
+		faults:  hem.faults,
+		from:    rt.id,
+		to:      to,
+		request: func(deliver func(), drop func()) droppableMessage {
+			return event.NewHttpRequestEvent(rt.id, to, req.Method, path, dataId(body), deliver, drop)
+		},
+		response: func(from int, res httpResult, respond func(), drop func()) droppableMessage {
+			result := ""
+			if res.err != nil {
+				result = res.err.Error()
+			} else {
+				result = fmt.Sprint(res.resp.StatusCode, " ", dataId(res.body))
+			}
+			return event.NewHttpResponseEvent(from, rt.id, req.Method, path, result, respond, drop)
+		},
+		send: func() httpResult {
+			resp, data, err := hem.send(req, body)
+			return httpResult{resp, data, err}
+		},
+		resend: func() { hem.send(req, body) },
+		lost:   httpResult{err: errRequestLost},
+	}.call()
+	return res.resp, res.err
+}
+
+// Send a copy of the request to the server and read the complete response.
+//
+// Returns the response together with its body.
+func (hem *HttpEventManager) send(req *http.Request, body []byte) (*http.Response, []byte, error) {
+	r := req.Clone(req.Context())
+	r.Body = http.NoBody
+	if len(body) > 0 {
+		r.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	resp, err := hem.transport.RoundTrip(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	// The server has handled the request once the response has been completely written
+	data, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(data))
+	return resp, data, nil
+}
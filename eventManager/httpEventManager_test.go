@@ -0,0 +1,163 @@
+package eventManager
+
+import (
+	"errors"
+	"gomc/event"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// A handler that counts the requests and echoes the body of the request
+type echoHandler struct {
+	calls int
+}
+
+func (h *echoHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.calls++
+	io.Copy(w, r.Body)
+}
+
+type httpResponse struct {
+	body string
+	err  error
+}
+
+// Start a server on node 1 and make a request from node 0 to the server.
+//
+// Returns when the request event has been added and node 0 is blocked.
+func startHttpRequest(t *testing.T, sp SimulationParameters, nextEvt chan error) (*echoHandler, chan httpResponse) {
+	t.Helper()
+	hem := NewHttpEventManager(sp)
+	handler := &echoHandler{}
+	srv := &http.Server{Handler: handler}
+	go srv.Serve(hem.Listen(1))
+	t.Cleanup(func() {
+		srv.Close()
+		hem.Close()
+	})
+
+	client := &http.Client{Transport: hem.Transport(0)}
+	responses := make(chan httpResponse)
+	go func() {
+		resp, err := client.Post(hem.URL(1)+"/put?key=a", "text/plain", strings.NewReader("value"))
+		if err != nil {
+			responses <- httpResponse{err: err}
+			return
+		}
+		body, err := io.ReadAll(resp.Body)
+		responses <- httpResponse{string(body), err}
+	}()
+	<-nextEvt
+	return handler, responses
+}
+
+func TestHttpRequest(t *testing.T) {
+	sch := NewMockScheduler()
+	nextEvt := make(chan error)
+	handler, responses := startHttpRequest(t, SimulationParameters{
+		EventAdder: sch,
+		NextEvt:    func(err error, _ int) { nextEvt <- err },
+	}, nextEvt)
+
+	if handler.calls != 0 {
+		t.Errorf("Did not expect the request to arrive before the request event is executed")
+	}
+	if id := string(sch.eventStack[0].Id()); !strings.HasPrefix(id, "HttpRequest 0-1 POST /put?key=a 5:") {
+		t.Errorf("Expected the id of the request to contain the method, the path and the body. Got: %v", id)
+	}
+	executePending[event.HttpRequestEvent](t, sch, nextEvt)
+	if err := <-nextEvt; err != nil {
+		t.Fatalf("Unexpected error executing the request: %v", err)
+	}
+	if handler.calls != 1 {
+		t.Errorf("Expected the request to be handled by the server. Got %v calls", handler.calls)
+	}
+	select {
+	case resp := <-responses:
+		t.Fatalf("Did not expect the request to return before the response event is executed. Got: %v", resp)
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	if id := string(sch.eventStack[0].Id()); !strings.HasPrefix(id, "HttpResponse 1-0 POST /put?key=a 200 5:") {
+		t.Errorf("Expected the id of the response to contain the status and the body. Got: %v", id)
+	}
+	executePending[event.HttpResponseEvent](t, sch, nextEvt)
+	resp := <-responses
+	if resp.err != nil {
+		t.Fatalf("Unexpected error from the request: %v", resp.err)
+	}
+	if resp.body != "value" {
+		t.Errorf("Expected the response of the server. Got: %q", resp.body)
+	}
+}
+
+func TestHttpRequestLost(t *testing.T) {
+	sch := NewMockScheduler()
+	nextEvt := make(chan error)
+	handler, responses := startHttpRequest(t, SimulationParameters{
+		EventAdder:    sch,
+		NextEvt:       func(err error, _ int) { nextEvt <- err },
+		NetworkFaults: NewNetworkFaults(1, 0),
+	}, nextEvt)
+
+	executeWithPrefix(t, sch, "Drop", nil)
+	// The request can no longer be delivered
+	executeWithPrefix(t, sch, "HttpRequest", nil)
+	if handler.calls != 0 {
+		t.Errorf("Did not expect the lost request to be handled by the server")
+	}
+
+	executePending[event.HttpResponseEvent](t, sch, nextEvt)
+	if resp := <-responses; !errors.Is(resp.err, errRequestLost) {
+		t.Errorf("Expected the request to return an error when it is lost. Got: %v", resp.err)
+	}
+}
+
+func TestHttpResponseLost(t *testing.T) {
+	sch := NewMockScheduler()
+	nextEvt := make(chan error)
+	handler, responses := startHttpRequest(t, SimulationParameters{
+		EventAdder:    removingScheduler{sch},
+		NextEvt:       func(err error, _ int) { nextEvt <- err },
+		NetworkFaults: NewNetworkFaults(1, 0),
+	}, nextEvt)
+
+	executeWithPrefix(t, sch, "HttpRequest", nil)
+	if handler.calls != 1 {
+		t.Errorf("Expected the request to be handled by the server. Got %v calls", handler.calls)
+	}
+	for _, evt := range sch.eventStack {
+		if strings.HasPrefix(string(evt.Id()), "HttpResponse") {
+			if _, ok := evt.(event.MessageEvent); !ok {
+				t.Errorf("Expected the response to be a MessageEvent. Got: %v", evt)
+			}
+		}
+	}
+
+	executeWithPrefix(t, sch, "Drop HttpResponse", nil)
+	// The delivery of the lost response is removed
+	if n := countWithPrefix(sch, "HttpResponse 1-0"); n != 0 {
+		t.Errorf("Expected the delivery of the lost response to be removed. Got: %v", sch.eventStack)
+	}
+
+	// The calling node is informed that the response was lost
+	executePending[event.HttpResponseEvent](t, sch, nextEvt)
+	if resp := <-responses; !errors.Is(resp.err, errRequestLost) {
+		t.Errorf("Expected the request to return an error when the response is lost. Got: %v", resp.err)
+	}
+}
+
+func TestHttpUnknownHost(t *testing.T) {
+	sch := NewMockScheduler()
+	hem := NewHttpEventManager(SimulationParameters{EventAdder: sch})
+	client := &http.Client{Transport: hem.Transport(0)}
+	if _, err := client.Get("http://example.com/"); err == nil {
+		t.Errorf("Expected an error when the host is not a node")
+	}
+	if len(sch.eventStack) != 0 {
+		t.Errorf("Did not expect events for a request to an unknown host. Got: %v", sch.eventStack)
+	}
+}
@@ -0,0 +1,285 @@
+package eventManager
+
+import (
+	"fmt"
+	"gomc/event"
+	"sync"
+)
+
+// A model of an unreliable network that can lose and duplicate messages.
+//
+// For each message sent using the Sender or the GrpcEventManager, the network adds events representing the message being lost or duplicated as alternatives to the delivery of the message.
+// Only one of the alternatives can happen for each message. A message can not be lost after it has been delivered.
+// The number of lost and duplicated messages is bounded for each run, to keep the state space finite.
+// Alternatives that can no longer happen, and the delivery of lost messages, are removed from the scheduler if it supports removing events. Otherwise they have no effect when they are executed.
+// Messages are reordered by the scheduler independently of the NetworkFaults.
+//
+// Identical messages that are in flight at the same time have the same event ids, so the scheduler can not distinguish their events.
+// An event of a message therefore applies to one of the identical messages that it can still apply to when it is executed.
+//
+// A new NetworkFaults must be created for each run.
+type NetworkFaults struct {
+	sync.Mutex
+
+	maxLosses     int
+	maxDuplicates int
+
+	losses     int
+	duplicates int
+
+	// The messages with a pending delivery or pending alternatives, by the id of the message, in the order they were added
+	pending map[event.EventId][]*messageStatus
+}
+
+// Create a new NetworkFaults
+//
+// maxLosses is the maximum number of messages that are lost in a run.
+// maxDuplicates is the maximum number of messages that are duplicated in a run.
+func NewNetworkFaults(maxLosses, maxDuplicates int) *NetworkFaults {
+	return &NetworkFaults{
+		maxLosses:     maxLosses,
+		maxDuplicates: maxDuplicates,
+
+		pending: make(map[event.EventId][]*messageStatus),
+	}
+}
+
+// The status of a single message.
+//
+// Used as a unique handle of the message, since identical messages have the same ids.
+type messageStatus struct {
+	delivered bool
+	dropped   bool
+	// True if either the drop or the duplicate alternative has happened
+	faulted bool
+
+	// The message and the callbacks provided when it was added
+	msg         event.MessageEvent
+	onDrop      func()
+	onDuplicate func()
+
+	// The EventAdder the events of the message were added to
+	ea EventAdder
+	// The ids of the delivery and the alternatives of the message
+	id, dropId, duplicateId event.EventId
+	// True if the alternative is pending
+	dropPending, duplicatePending bool
+}
+
+// Returns true if the delivery of the message is pending
+func (status *messageStatus) inFlight() bool {
+	return !status.delivered && !status.dropped
+}
+
+// A pending event that can no longer happen
+type deadAlternative struct {
+	ea EventAdder
+	id event.EventId
+}
+
+// Add the message to the EventAdder together with the alternatives of losing and duplicating the message.
+//
+// onDrop is called when the message is lost, and should release any resources held by the message.
+// onDuplicate is called when the message is duplicated, and should add a new event delivering the message.
+// If onDuplicate is nil the message can not be duplicated, e.g. because a duplicate would have no effect.
+// Alternatives are only added if the budget of the run has not been used.
+func (nf *NetworkFaults) addMessage(ea EventAdder, msg event.MessageEvent, onDrop func(), onDuplicate func()) {
+	status := &messageStatus{msg: msg, onDrop: onDrop, onDuplicate: onDuplicate, ea: ea, id: msg.Id()}
+	dropEvt := event.NewMessageDrop(msg, func() { nf.drop(status) })
+	duplicateEvt := event.NewMessageDuplicate(msg, func() { nf.duplicate(status) })
+	status.dropId, status.duplicateId = dropEvt.Id(), duplicateEvt.Id()
+
+	nf.Lock()
+	status.dropPending, status.duplicatePending = nf.losses < nf.maxLosses, onDuplicate != nil && nf.duplicates < nf.maxDuplicates
+	nf.pending[status.id] = append(nf.pending[status.id], status)
+	nf.Unlock()
+
+	ea.AddEvent(faultyMessage{MessageEvent: msg, nf: nf, status: status})
+	if status.dropPending {
+		ea.AddEvent(dropEvt)
+	}
+	if status.duplicatePending {
+		ea.AddEvent(duplicateEvt)
+	}
+}
+
+// Returns the message that an event added for the provided message applies to.
+//
+// The event applies to the provided message if applies returns true for it.
+// Otherwise the scheduler may have removed the event of an identical message instead, and the event applies to the first identical message it applies to.
+// Returns nil if the event applies to no message.
+// Must be called while holding the lock.
+func (nf *NetworkFaults) resolve(status *messageStatus, applies func(*messageStatus) bool) *messageStatus {
+	if applies(status) {
+		return status
+	}
+	for _, s := range nf.pending[status.id] {
+		if applies(s) {
+			return s
+		}
+	}
+	return nil
+}
+
+// Lose the message if it has not been delivered, no other fault has happened to it and the budget allows it.
+//
+// The pending delivery of the lost message is removed.
+func (nf *NetworkFaults) drop(status *messageStatus) {
+	nf.Lock()
+	status = nf.resolve(status, func(s *messageStatus) bool { return s.dropPending })
+	if status == nil {
+		nf.Unlock()
+		return
+	}
+	// The alternative is being executed, and is no longer pending
+	status.dropPending = false
+	ok := !status.delivered && !status.faulted && nf.losses < nf.maxLosses
+	if ok {
+		nf.losses++
+		status.dropped = true
+		status.faulted = true
+	}
+	dead := nf.deadAlternatives()
+	nf.Unlock()
+
+	if ok {
+		dead = append(dead, deadAlternative{status.ea, status.id})
+	}
+	removeAlternatives(dead)
+	if ok {
+		status.onDrop()
+	}
+}
+
+// Duplicate the message if no other fault has happened to it and the budget allows it.
+func (nf *NetworkFaults) duplicate(status *messageStatus) {
+	nf.Lock()
+	status = nf.resolve(status, func(s *messageStatus) bool { return s.duplicatePending })
+	if status == nil {
+		nf.Unlock()
+		return
+	}
+	// The alternative is being executed, and is no longer pending
+	status.duplicatePending = false
+	ok := !status.faulted && nf.duplicates < nf.maxDuplicates
+	if ok {
+		nf.duplicates++
+		status.faulted = true
+	}
+	dead := nf.deadAlternatives()
+	nf.Unlock()
+
+	removeAlternatives(dead)
+	if ok {
+		status.onDuplicate()
+	}
+}
+
+// Lose the message regardless of the budget, e.g. because the network is partitioned.
+//
+// Returns the message that was lost, or nil if no message is in flight.
+func (nf *NetworkFaults) lose(status *messageStatus) *messageStatus {
+	nf.Lock()
+	status = nf.resolve(status, (*messageStatus).inFlight)
+	if status != nil {
+		status.dropped = true
+		status.faulted = true
+	}
+	dead := nf.deadAlternatives()
+	nf.Unlock()
+
+	removeAlternatives(dead)
+	return status
+}
+
+// Mark the message as delivered.
+//
+// Returns the message that should be delivered, or nil if no message is in flight, e.g. because the message has been lost.
+func (nf *NetworkFaults) deliver(status *messageStatus) *messageStatus {
+	nf.Lock()
+	status = nf.resolve(status, (*messageStatus).inFlight)
+	if status != nil {
+		status.delivered = true
+	}
+	dead := nf.deadAlternatives()
+	nf.Unlock()
+
+	removeAlternatives(dead)
+	return status
+}
+
+// Returns the pending alternatives that can no longer happen, and marks them as no longer pending.
+//
+// A message can not be lost after it has been delivered, only one fault can happen to each message, and no faults can happen once the budget is used.
+// Messages without a pending delivery or pending alternatives are no longer tracked.
+// Must be called while holding the lock.
+func (nf *NetworkFaults) deadAlternatives() []deadAlternative {
+	dead := []deadAlternative{}
+	for id, statuses := range nf.pending {
+		remaining := statuses[:0]
+		for _, status := range statuses {
+			if status.dropPending && (status.delivered || status.faulted || nf.losses >= nf.maxLosses) {
+				dead = append(dead, deadAlternative{status.ea, status.dropId})
+				status.dropPending = false
+			}
+			if status.duplicatePending && (status.faulted || nf.duplicates >= nf.maxDuplicates) {
+				dead = append(dead, deadAlternative{status.ea, status.duplicateId})
+				status.duplicatePending = false
+			}
+			if status.inFlight() || status.dropPending || status.duplicatePending {
+				remaining = append(remaining, status)
+			}
+		}
+		if len(remaining) == 0 {
+			delete(nf.pending, id)
+		} else {
+			nf.pending[id] = remaining
+		}
+	}
+	return dead
+}
+
+// Remove the events from the scheduler if it supports removing events.
+func removeAlternatives(dead []deadAlternative) {
+	for _, alt := range dead {
+		if r, ok := alt.ea.(EventRemover); ok {
+			r.RemoveEvent(alt.id)
+		}
+	}
+}
+
+// A message that can be lost.
+//
+// Has the same id as the wrapped message, and delivers the message unless it has been lost.
+type faultyMessage struct {
+	event.MessageEvent
+	nf     *NetworkFaults
+	status *messageStatus
+}
+
+// Deliver the message, or signal that the event is complete if the message has been lost
+func (fm faultyMessage) Execute(node any, errorChan chan error) {
+	status := fm.nf.deliver(fm.status)
+	if status == nil {
+		errorChan <- nil
+		return
+	}
+	status.msg.Execute(node, errorChan)
+}
+
+// Lose the message without delivering it.
+//
+// Releases the resources held by the message if the message is a DroppableEvent.
+func (fm faultyMessage) Drop() {
+	status := fm.nf.lose(fm.status)
+	if status == nil {
+		return
+	}
+	if d, ok := status.msg.(event.DroppableEvent); ok {
+		d.Drop()
+	}
+}
+
+func (fm faultyMessage) String() string {
+	return fmt.Sprint(fm.MessageEvent)
+}
@@ -0,0 +1,188 @@
+package eventManager
+
+import (
+	"gomc/event"
+	"strings"
+	"testing"
+)
+
+type countingNode struct {
+	received int
+}
+
+func (n *countingNode) Foo(msg []byte) {
+	n.received++
+}
+
+// Find and execute the pending event whose id has the provided prefix
+func executeWithPrefix(t *testing.T, sch *MockScheduler, prefix string, node any) {
+	t.Helper()
+	for i, evt := range sch.eventStack {
+		if strings.HasPrefix(string(evt.Id()), prefix) {
+			sch.eventStack = append(sch.eventStack[:i], sch.eventStack[i+1:]...)
+			errorChan := make(chan error, 1)
+			evt.Execute(node, errorChan)
+			if err := <-errorChan; err != nil {
+				t.Fatalf("Unexpected error executing %v: %v", evt, err)
+			}
+			return
+		}
+	}
+	t.Fatalf("No pending event with prefix %v. Pending: %v", prefix, sch.eventStack)
+}
+
+func countWithPrefix(sch *MockScheduler, prefix string) int {
+	count := 0
+	for _, evt := range sch.eventStack {
+		if strings.HasPrefix(string(evt.Id()), prefix) {
+			count++
+		}
+	}
+	return count
+}
+
+func TestSenderNetworkFaults(t *testing.T) {
+	sch := NewMockScheduler()
+	sender := NewSender(SimulationParameters{
+		EventAdder:    sch,
+		NetworkFaults: NewNetworkFaults(1, 1),
+	})
+	send := sender.SendFunc(0)
+	send(1, "Foo", []byte("Foo"))
+
+	if len(sch.eventStack) != 3 {
+		t.Fatalf("Expected the message and 2 alternatives to be added. Got: %v", sch.eventStack)
+	}
+	expected := event.NewMessageHandlerEvent(0, 1, "Foo", []byte("Foo"))
+	if sch.eventStack[0].Id() != expected.Id() {
+		t.Errorf("Expected the message to keep its id. Got: %v. Expected: %v", sch.eventStack[0].Id(), expected.Id())
+	}
+
+	node := &countingNode{}
+	executeWithPrefix(t, sch, "Drop", node)
+	executeWithPrefix(t, sch, "Message", node)
+	if node.received != 0 {
+		t.Errorf("Expected the lost message not to be delivered. Received %v messages", node.received)
+	}
+
+	// The duplicate alternative is no longer possible
+	executeWithPrefix(t, sch, "Duplicate", node)
+	if len(sch.eventStack) != 0 {
+		t.Errorf("Expected no events to be added. Got: %v", sch.eventStack)
+	}
+
+	// The budget of lost messages has been used
+	send(1, "Foo", []byte("Bar"))
+	if countWithPrefix(sch, "Drop") != 0 {
+		t.Errorf("Expected no drop alternative when the budget is used. Got: %v", sch.eventStack)
+	}
+	if countWithPrefix(sch, "Duplicate") != 1 {
+		t.Errorf("Expected a duplicate alternative. Got: %v", sch.eventStack)
+	}
+}
+
+func TestSenderNetworkFaultsDuplicate(t *testing.T) {
+	sch := NewMockScheduler()
+	sender := NewSender(SimulationParameters{
+		EventAdder:    sch,
+		NetworkFaults: NewNetworkFaults(1, 1),
+	})
+	send := sender.SendFunc(0)
+	send(1, "Foo", []byte("Foo"))
+
+	node := &countingNode{}
+	executeWithPrefix(t, sch, "Message", node)
+	// A delivered message can not be lost
+	executeWithPrefix(t, sch, "Drop", node)
+	executeWithPrefix(t, sch, "Duplicate", node)
+	if countWithPrefix(sch, "Message") != 1 {
+		t.Fatalf("Expected a copy of the message to be added. Got: %v", sch.eventStack)
+	}
+	executeWithPrefix(t, sch, "Message", node)
+	if node.received != 2 {
+		t.Errorf("Expected the message to be delivered twice. Received %v messages", node.received)
+	}
+}
+
+func TestNetworkFaultsRemoveAlternatives(t *testing.T) {
+	sch := NewMockScheduler()
+	sender := NewSender(SimulationParameters{
+		EventAdder:    removingScheduler{sch},
+		NetworkFaults: NewNetworkFaults(1, 1),
+	})
+	send := sender.SendFunc(0)
+	send(1, "Foo", []byte("Foo"))
+	send(1, "Foo", []byte("Bar"))
+
+	// A delivered message can no longer be lost
+	node := &countingNode{}
+	executeWithPrefix(t, sch, "Message", node)
+	if countWithPrefix(sch, "Drop") != 1 || countWithPrefix(sch, "Duplicate") != 2 {
+		t.Fatalf("Expected the drop alternative of the delivered message to be removed. Got: %v", sch.eventStack)
+	}
+
+	// Losing the other message uses the budget, so no alternatives can happen to it
+	executeWithPrefix(t, sch, "Drop", node)
+	if countWithPrefix(sch, "Duplicate") != 1 {
+		t.Errorf("Expected the duplicate alternative of the lost message to be removed. Got: %v", sch.eventStack)
+	}
+
+	// Duplicating the delivered message uses the budget
+	executeWithPrefix(t, sch, "Duplicate", node)
+	if countWithPrefix(sch, "Drop") != 0 || countWithPrefix(sch, "Duplicate") != 0 {
+		t.Errorf("Expected all alternatives to be removed. Got: %v", sch.eventStack)
+	}
+}
+
+func TestNetworkFaultsRemoveLostDelivery(t *testing.T) {
+	sch := NewMockScheduler()
+	sender := NewSender(SimulationParameters{
+		EventAdder:    removingScheduler{sch},
+		NetworkFaults: NewNetworkFaults(1, 1),
+	})
+	sender.SendFunc(0)(1, "Foo", []byte("Foo"))
+
+	node := &countingNode{}
+	executeWithPrefix(t, sch, "Drop", node)
+	if len(sch.eventStack) != 0 {
+		t.Errorf("Expected the delivery and the duplicate alternative of the lost message to be removed. Got: %v", sch.eventStack)
+	}
+}
+
+func TestNetworkFaultsIdenticalMessages(t *testing.T) {
+	sch := NewMockScheduler()
+	sender := NewSender(SimulationParameters{
+		EventAdder:    removingScheduler{sch},
+		NetworkFaults: NewNetworkFaults(1, 1),
+	})
+	send := sender.SendFunc(0)
+	send(1, "Foo", []byte("Foo"))
+	send(1, "Foo", []byte("Foo"))
+
+	// Deliver the second message. The scheduler removes the drop alternative of the first message, since the alternatives have the same id
+	node := &countingNode{}
+	for i := len(sch.eventStack) - 1; i >= 0; i-- {
+		if evt := sch.eventStack[i]; strings.HasPrefix(string(evt.Id()), "Message") {
+			sch.eventStack = append(sch.eventStack[:i], sch.eventStack[i+1:]...)
+			errorChan := make(chan error, 1)
+			evt.Execute(node, errorChan)
+			<-errorChan
+			break
+		}
+	}
+	if countWithPrefix(sch, "Drop") != 1 {
+		t.Fatalf("Expected a drop alternative to remain. Got: %v", sch.eventStack)
+	}
+
+	// The remaining drop alternative loses the message that is still in flight
+	executeWithPrefix(t, sch, "Drop", node)
+	if countWithPrefix(sch, "Message") != 0 {
+		t.Errorf("Expected the delivery of the lost message to be removed. Got: %v", sch.eventStack)
+	}
+	if node.received != 1 {
+		t.Errorf("Expected only the delivered message to be received. Received %v messages", node.received)
+	}
+	if countWithPrefix(sch, "Duplicate") != 1 {
+		t.Errorf("Expected only the delivered message to be duplicated. Got: %v", sch.eventStack)
+	}
+}
@@ -0,0 +1,509 @@
+package eventManager
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"gomc/event"
+	"io"
+	"net"
+	"sync"
+	"time"
+)
+
+// An Event Manager that provides an in-memory network of net.Conn connections between the nodes.
+//
+// Each node listens on the listener created by Listen, and connections to the listener are created by Dial.
+// Connections are established immediately, while the data written to a connection and closing the connection are withheld until their NetworkEvents are executed.
+// Each call to Write is represented by a single NetworkEvent.
+// The operations in each direction of a connection are delivered in order, and are not affected by network faults or partitions.
+//
+// Data must be read in a separate goroutine that is blocked in Read when the data is delivered, e.g. a read loop.
+// The event delivering the data is completed when the goroutine has read all the delivered data and calls Read again, or when it closes the connection.
+// Operations are not delivered to a connection before a goroutine has started reading from it,
+// so every connection must be read from, e.g. to detect that the connection has been closed.
+// After Read returns an error the connection must be closed.
+//
+// The NetworkManager subscribes to the status updates of the nodes that call Listen or Dial, and crashes a node when the node detects its own crash.
+// The listener and the connections of the crashed node are closed,
+// and the connections are reset at the other nodes once the data that has already been written by the node is delivered.
+// Data is delivered to the node until it detects its crash, so the node must keep reading from its connections until then.
+// Crash can also be called directly to close the connections immediately, e.g. by the function performing the crash on the node, or if the SimulationParameters do not contain CrashSubscribe.
+//
+// NetworkEvents are not MessageEvents, so the connections are not affected by the NetworkFaults or by partitions of the network.
+//
+// Close must be called when the run ends, e.g. by the stop function, so that no goroutine is left blocked.
+// A new NetworkManager must be created for each run.
+type NetworkManager struct {
+	ea             EventAdder
+	nextEvt        func(error, int)
+	crashSubscribe func(int, func(int, bool))
+
+	lock      sync.Mutex
+	listeners map[int]*netListener
+	// The connections of each node
+	conns map[int][]*netConn
+	// The number of connections created from one node to another
+	connCount map[string]int
+	// The nodes that have subscribed to status updates
+	subscribed map[int]bool
+	// True if the network has been closed, and no more operations are delivered
+	closed bool
+}
+
+// Create a new NetworkManager
+func NewNetworkManager(sp SimulationParameters) *NetworkManager {
+	return &NetworkManager{
+		ea:             sp.EventAdder,
+		nextEvt:        sp.NextEvt,
+		crashSubscribe: sp.CrashSubscribe,
+
+		listeners:  make(map[int]*netListener),
+		conns:      make(map[int][]*netConn),
+		connCount:  make(map[string]int),
+		subscribed: make(map[int]bool),
+	}
+}
+
+// Returned by Dial if the listener of the node has been closed
+var errConnectionRefused = errors.New("networkManager: connection refused")
+
+// Returned by operations on a connection whose other end has crashed
+var errConnectionReset = errors.New("networkManager: connection reset by peer")
+
+// The address of a node in the in-memory network
+type NodeAddr int
+
+func (a NodeAddr) Network() string {
+	return "gomc"
+}
+
+func (a NodeAddr) String() string {
+	return fmt.Sprint("node-", int(a))
+}
+
+// Create the listener of the node.
+//
+// Returns the same listener if it is called multiple times with the same id, unless the listener has been closed.
+func (nm *NetworkManager) Listen(id int) net.Listener {
+	nm.subscribe(id)
+	nm.lock.Lock()
+	defer nm.lock.Unlock()
+	l, ok := nm.listeners[id]
+	if !ok || l.closed {
+		l = nm.newListener(id)
+	}
+	return l
+}
+
+// Connect the node with id from to the listener of the node with id to.
+//
+// The connection is established immediately, and is accepted by the next call to Accept on the listener.
+// The listener is created if the node does not listen yet, so the nodes can be connected in any order.
+// Returns an error if the listener has been closed.
+func (nm *NetworkManager) Dial(from, to int) (net.Conn, error) {
+	nm.subscribe(from)
+	nm.lock.Lock()
+	defer nm.lock.Unlock()
+	l, ok := nm.listeners[to]
+	if !ok {
+		l = nm.newListener(to)
+	}
+	if l.closed {
+		return nil, errConnectionRefused
+	}
+
+	pair := fmt.Sprint(from, "-", to)
+	conn := fmt.Sprint(pair, " ", nm.connCount[pair])
+	nm.connCount[pair]++
+
+	client := nm.newConn(from, to, conn)
+	server := nm.newConn(to, from, conn)
+	client.peer, server.peer = server, client
+
+	l.backlog = append(l.backlog, server)
+	l.cond.Broadcast()
+	return client, nil
+}
+
+// Close the listener and the connections of the crashed node.
+//
+// Goroutines of the node that are blocked in Accept or Read return net.ErrClosed.
+// The connections are reset at the other nodes once the data that has already been written by the node is delivered.
+func (nm *NetworkManager) Crash(id int) {
+	nm.lock.Lock()
+	if l, ok := nm.listeners[id]; ok {
+		l.close()
+	}
+	open := []*netConn{}
+	for _, c := range nm.conns[id] {
+		if !c.closed {
+			// The node is not executing an event when it crashes, so no event is waiting for it
+			c.close()
+			open = append(open, c)
+		}
+	}
+	delete(nm.conns, id)
+	nm.lock.Unlock()
+
+	nm.reset(open)
+}
+
+// Subscribe to the status updates of the node, so that the node is crashed when it detects its own crash.
+//
+// Each node is only subscribed once.
+func (nm *NetworkManager) subscribe(id int) {
+	if nm.crashSubscribe == nil {
+		return
+	}
+	nm.lock.Lock()
+	subscribed := nm.subscribed[id]
+	nm.subscribed[id] = true
+	nm.lock.Unlock()
+	if subscribed {
+		return
+	}
+	nm.crashSubscribe(id, func(crashed int, status bool) {
+		// Other nodes can be falsely suspected, but a node only detects its own crash when it has crashed
+		if crashed == id && !status {
+			nm.Crash(id)
+		}
+	})
+}
+
+// Close all listeners and connections without delivering any more operations.
+//
+// Goroutines blocked in Accept or Read return net.ErrClosed.
+// Is called when the run ends, and can be called multiple times.
+func (nm *NetworkManager) Close() {
+	nm.lock.Lock()
+	defer nm.lock.Unlock()
+	nm.closed = true
+	for _, l := range nm.listeners {
+		l.close()
+	}
+	for _, conns := range nm.conns {
+		for _, c := range conns {
+			c.close()
+		}
+	}
+}
+
+// Must be called while holding the lock
+func (nm *NetworkManager) newListener(id int) *netListener {
+	l := &netListener{nm: nm, id: id, cond: sync.NewCond(&nm.lock)}
+	nm.listeners[id] = l
+	return l
+}
+
+// Must be called while holding the lock
+func (nm *NetworkManager) newConn(local, remote int, conn string) *netConn {
+	c := &netConn{nm: nm, local: local, remote: remote, conn: conn, cond: sync.NewCond(&nm.lock)}
+	nm.conns[local] = append(nm.conns[local], c)
+	return c
+}
+
+// Reset the closed connections at the other end of the connections
+func (nm *NetworkManager) reset(conns []*netConn) {
+	for _, c := range conns {
+		peer := c.peer
+		c.enqueue("Reset", "", func() bool {
+			return peer.receive(func() { peer.reset = true })
+		})
+	}
+}
+
+// A stable representation of data written to a connection
+func dataId(data []byte) string {
+	sum := sha256.Sum256(data)
+	return fmt.Sprint(len(data), ":", hex.EncodeToString(sum[:16]))
+}
+
+// A listener in the in-memory network
+type netListener struct {
+	nm   *NetworkManager
+	id   int
+	cond *sync.Cond
+
+	// Connections that have not been accepted
+	backlog []*netConn
+	closed  bool
+}
+
+// Wait for and return the next connection to the node.
+//
+// Accepting a connection is not an event, and the node should only start reading from the connection when it is accepted.
+func (l *netListener) Accept() (net.Conn, error) {
+	l.nm.lock.Lock()
+	defer l.nm.lock.Unlock()
+	for len(l.backlog) == 0 && !l.closed {
+		l.cond.Wait()
+	}
+	if l.closed {
+		return nil, net.ErrClosed
+	}
+	c := l.backlog[0]
+	l.backlog = l.backlog[1:]
+	return c, nil
+}
+
+// Close the listener.
+//
+// Connections that have not been accepted are reset.
+func (l *netListener) Close() error {
+	l.nm.lock.Lock()
+	if l.closed {
+		l.nm.lock.Unlock()
+		return net.ErrClosed
+	}
+	backlog := l.close()
+	for _, c := range backlog {
+		c.close()
+	}
+	l.nm.lock.Unlock()
+
+	l.nm.reset(backlog)
+	return nil
+}
+
+func (l *netListener) Addr() net.Addr {
+	return NodeAddr(l.id)
+}
+
+// Close the listener and return the connections that have not been accepted.
+//
+// Must be called while holding the lock
+func (l *netListener) close() []*netConn {
+	backlog := l.backlog
+	l.backlog = nil
+	l.closed = true
+	l.cond.Broadcast()
+	return backlog
+}
+
+// An operation on a connection that is withheld until its event is executed
+type netOp struct {
+	kind    string
+	seq     int
+	payload string
+	deliver func() bool
+}
+
+// One end of an in-memory connection
+type netConn struct {
+	nm   *NetworkManager
+	cond *sync.Cond
+
+	local  int
+	remote int
+	// Identifies the connection among the connections between the nodes
+	conn string
+	peer *netConn
+
+	// The operations performed on this end that have not been delivered. Only the first operation has an event.
+	queue []netOp
+	seq   int
+
+	// Data that has been delivered to this end but not read
+	buf []byte
+	// True if the other end has been closed
+	eof bool
+	// True if the other end has crashed
+	reset bool
+	// True if this end has been closed
+	closed bool
+
+	// True if a goroutine has started reading from this end
+	reading bool
+	// True if the node is waiting in Read and no delivered data is available
+	waiting bool
+	// True if the node must signal that the event delivering the last operation is completed
+	pending bool
+}
+
+// Read data that has been delivered to the connection.
+//
+// Signals that the event delivering the previous operation is completed if all the delivered data has been read.
+func (c *netConn) Read(b []byte) (int, error) {
+	c.nm.lock.Lock()
+	defer c.nm.lock.Unlock()
+	if !c.reading {
+		c.reading = true
+		c.cond.Broadcast()
+	}
+	if len(c.buf) == 0 && !c.closed {
+		signal := c.pending
+		c.pending = false
+		c.waiting = true
+		if signal {
+			c.nm.lock.Unlock()
+			c.nm.nextEvt(nil, c.local)
+			c.nm.lock.Lock()
+		}
+	}
+	for len(c.buf) == 0 && !c.eof && !c.reset && !c.closed {
+		c.cond.Wait()
+	}
+	c.waiting = false
+
+	switch {
+	case c.closed:
+		return 0, net.ErrClosed
+	case len(c.buf) > 0:
+		n := copy(b, c.buf)
+		c.buf = c.buf[n:]
+		return n, nil
+	case c.reset:
+		return 0, errConnectionReset
+	default:
+		return 0, io.EOF
+	}
+}
+
+// Write the data to the connection.
+//
+// The data is delivered once its event is executed.
+func (c *netConn) Write(b []byte) (int, error) {
+	c.nm.lock.Lock()
+	closed, reset := c.closed, c.reset
+	c.nm.lock.Unlock()
+	if closed {
+		return 0, net.ErrClosed
+	}
+	if reset {
+		return 0, errConnectionReset
+	}
+	if len(b) == 0 {
+		return 0, nil
+	}
+
+	data := append([]byte(nil), b...)
+	peer := c.peer
+	c.enqueue("Write", dataId(data), func() bool {
+		return peer.receive(func() { peer.buf = append(peer.buf, data...) })
+	})
+	return len(b), nil
+}
+
+// Close the connection.
+//
+// Goroutines blocked in Read return net.ErrClosed, and the other end of the connection reads io.EOF once the closing event is executed.
+func (c *netConn) Close() error {
+	c.nm.lock.Lock()
+	if c.closed {
+		c.nm.lock.Unlock()
+		return net.ErrClosed
+	}
+	signal := c.close()
+	c.nm.lock.Unlock()
+
+	peer := c.peer
+	c.enqueue("Close", "", func() bool {
+		return peer.receive(func() { peer.eof = true })
+	})
+	if signal {
+		c.nm.nextEvt(nil, c.local)
+	}
+	return nil
+}
+
+func (c *netConn) LocalAddr() net.Addr {
+	return NodeAddr(c.local)
+}
+
+func (c *netConn) RemoteAddr() net.Addr {
+	return NodeAddr(c.remote)
+}
+
+// Deadlines are ignored, since real time does not make sense during simulations.
+func (c *netConn) SetDeadline(t time.Time) error {
+	return nil
+}
+
+// Deadlines are ignored, since real time does not make sense during simulations.
+func (c *netConn) SetReadDeadline(t time.Time) error {
+	return nil
+}
+
+// Deadlines are ignored, since real time does not make sense during simulations.
+func (c *netConn) SetWriteDeadline(t time.Time) error {
+	return nil
+}
+
+// Close this end of the connection.
+//
+// Returns true if the node must signal that the event delivering the last operation is completed.
+// Must be called while holding the lock
+func (c *netConn) close() bool {
+	signal := c.pending
+	c.pending = false
+	c.waiting = false
+	c.closed = true
+	c.buf = nil
+	c.cond.Broadcast()
+	return signal
+}
+
+// Add an operation performed on this end of the connection.
+//
+// An event is added for the operation if it is the only operation that has not been delivered.
+func (c *netConn) enqueue(kind string, payload string, deliver func() bool) {
+	c.nm.lock.Lock()
+	op := netOp{kind: kind, seq: c.seq, payload: payload, deliver: deliver}
+	c.seq++
+	c.queue = append(c.queue, op)
+	first := len(c.queue) == 1 && !c.nm.closed
+	c.nm.lock.Unlock()
+	if first {
+		c.nm.ea.AddEvent(c.event(op))
+	}
+}
+
+func (c *netConn) event(op netOp) event.NetworkEvent {
+	return event.NewNetworkEvent(c.local, c.remote, c.conn, op.kind, op.seq, op.payload, c.deliver)
+}
+
+// Deliver the first operation performed on this end and add an event for the next operation.
+//
+// Returns true if the event is completed.
+func (c *netConn) deliver() bool {
+	c.nm.lock.Lock()
+	op := c.queue[0]
+	c.queue = c.queue[1:]
+	next := len(c.queue) > 0
+	var nextOp netOp
+	if next {
+		nextOp = c.queue[0]
+	}
+	c.nm.lock.Unlock()
+	if next {
+		c.nm.ea.AddEvent(c.event(nextOp))
+	}
+	return op.deliver()
+}
+
+// Deliver an operation from the other end of the connection.
+//
+// Waits until a goroutine has started reading from the connection.
+// Returns true if the reading goroutine is not waiting for the operation, and the event is completed.
+// Otherwise the reading goroutine signals when it has handled the operation.
+// Operations delivered to a closed connection are discarded.
+func (c *netConn) receive(op func()) bool {
+	c.nm.lock.Lock()
+	defer c.nm.lock.Unlock()
+	for !c.reading && !c.closed {
+		c.cond.Wait()
+	}
+	if c.closed {
+		return true
+	}
+	op()
+	expect := c.waiting
+	if expect {
+		c.waiting = false
+		c.pending = true
+	}
+	c.cond.Broadcast()
+	return !expect
+}
@@ -0,0 +1,217 @@
+package eventManager
+
+import (
+	"errors"
+	"gomc/event"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/exp/slices"
+)
+
+// Wait until a goroutine is blocked in Read on the connection
+func waitForRead(t *testing.T, conn net.Conn) {
+	t.Helper()
+	c := conn.(*netConn)
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		c.nm.lock.Lock()
+		waiting := c.waiting
+		c.nm.lock.Unlock()
+		if waiting {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("Timed out waiting for a goroutine to read from the connection")
+}
+
+// Write each chunk read from the connection back to the sender until the connection is closed
+func echo(conn net.Conn) {
+	defer conn.Close()
+	buf := make([]byte, 16)
+	for {
+		n, err := conn.Read(buf)
+		if err != nil {
+			return
+		}
+		conn.Write(buf[:n])
+	}
+}
+
+// Send two chunks from node 0 to an echo server on node 1, and close the connection once both are echoed
+func runEcho(t *testing.T) ([]event.EventId, string) {
+	sch, nextEvt, sp := newStreamParameters()
+	nm := NewNetworkManager(sp)
+	t.Cleanup(nm.Close)
+
+	lis := nm.Listen(1)
+	client, err := nm.Dial(0, 1)
+	if err != nil {
+		t.Fatalf("Unexpected error dialing node 1: %v", err)
+	}
+	server, err := lis.Accept()
+	if err != nil {
+		t.Fatalf("Unexpected error accepting the connection: %v", err)
+	}
+	if server.RemoteAddr() != NodeAddr(0) || client.RemoteAddr() != NodeAddr(1) {
+		t.Errorf("Expected the connection to be between node 0 and node 1. Got: %v and %v", client.RemoteAddr(), server.RemoteAddr())
+	}
+	go echo(server)
+
+	received := make(chan string)
+	go func() {
+		data := []byte{}
+		buf := make([]byte, 16)
+		for len(data) < 2 {
+			n, err := client.Read(buf)
+			if err != nil {
+				break
+			}
+			data = append(data, buf[:n]...)
+		}
+		client.Close()
+		received <- string(data)
+	}()
+
+	client.Write([]byte("a"))
+	client.Write([]byte("b"))
+	ids := executeStreamEvents(t, sch, nextEvt)
+	return ids, <-received
+}
+
+func TestNetworkEcho(t *testing.T) {
+	ids, data := runEcho(t)
+	if data != "ab" {
+		t.Errorf("Expected to receive the echoed data. Got: %q", data)
+	}
+	expected := []string{"0-1 0-1 0 Write 0", "0-1 0-1 0 Write 1", "1-0 0-1 0 Write 0", "1-0 0-1 0 Write 1", "0-1 0-1 0 Close 2", "1-0 0-1 0 Close 2"}
+	if len(ids) != len(expected) {
+		t.Fatalf("Expected %v events. Got: %v", len(expected), ids)
+	}
+	for i, prefix := range expected {
+		if !strings.HasPrefix(string(ids[i]), "Network "+prefix) {
+			t.Errorf("Expected event %v to be %v. Got: %v", i, prefix, ids[i])
+		}
+	}
+
+	again, _ := runEcho(t)
+	if !slices.Equal(ids, again) {
+		t.Errorf("Expected the events to have the same ids in every run. Got: %v and %v", ids, again)
+	}
+}
+
+func TestNetworkCrash(t *testing.T) {
+	sch, nextEvt, sp := newStreamParameters()
+	nm := NewNetworkManager(sp)
+	t.Cleanup(nm.Close)
+
+	lis := nm.Listen(1)
+	client, _ := nm.Dial(0, 1)
+	server, _ := lis.Accept()
+
+	errs := make(chan error)
+	received := make(chan string)
+	go func() {
+		buf := make([]byte, 16)
+		n, _ := server.Read(buf)
+		received <- string(buf[:n])
+		_, err := server.Read(buf)
+		server.Close()
+		errs <- err
+	}()
+
+	client.Write([]byte("a"))
+	nm.Crash(0)
+	if _, err := client.Write([]byte("b")); !errors.Is(err, net.ErrClosed) {
+		t.Errorf("Expected writes on the connection of a crashed node to fail. Got: %v", err)
+	}
+
+	// The data written before the crash is delivered before the connection is reset
+	done := make(chan []event.EventId)
+	go func() { done <- executeStreamEvents(t, sch, nextEvt) }()
+	if data := <-received; data != "a" {
+		t.Errorf("Expected to receive the data written before the crash. Got: %q", data)
+	}
+	if err := <-errs; !errors.Is(err, errConnectionReset) {
+		t.Errorf("Expected the connection to be reset. Got: %v", err)
+	}
+	ids := <-done
+	// Closing the connection after the reset is discarded at the crashed node
+	if len(ids) != 3 || !strings.Contains(string(ids[1]), "Reset") || !strings.HasPrefix(string(ids[2]), "Network 1-0 0-1 0 Close") {
+		t.Errorf("Expected the write to be followed by a reset. Got: %v", ids)
+	}
+
+	nm.Crash(1)
+	if _, err := nm.Dial(0, 1); !errors.Is(err, errConnectionRefused) {
+		t.Errorf("Expected dialing a crashed node to be refused. Got: %v", err)
+	}
+	nm.Listen(1)
+	if _, err := nm.Dial(0, 1); err != nil {
+		t.Errorf("Expected to connect to the node once it listens again. Got: %v", err)
+	}
+}
+
+func TestNetworkCrashSubscribe(t *testing.T) {
+	_, _, sp := newStreamParameters()
+	callbacks := map[int]func(int, bool){}
+	sp.CrashSubscribe = func(id int, callback func(int, bool)) { callbacks[id] = callback }
+	nm := NewNetworkManager(sp)
+	t.Cleanup(nm.Close)
+
+	nm.Listen(1)
+	nm.Dial(0, 1)
+	if len(callbacks) != 2 {
+		t.Fatalf("Expected the listening and the dialing node to be subscribed. Got: %v", callbacks)
+	}
+
+	// A suspicion of another node does not crash the node
+	callbacks[0](1, false)
+	if _, err := nm.Dial(0, 1); err != nil {
+		t.Errorf("Did not expect a suspicion of node 1 to close its listener. Got: %v", err)
+	}
+
+	// The node detects its own crash
+	callbacks[1](1, false)
+	if _, err := nm.Dial(0, 1); !errors.Is(err, errConnectionRefused) {
+		t.Errorf("Expected dialing a crashed node to be refused. Got: %v", err)
+	}
+}
+
+func TestNetworkClosed(t *testing.T) {
+	sch, _, sp := newStreamParameters()
+	nm := NewNetworkManager(sp)
+
+	lis := nm.Listen(1)
+	client, _ := nm.Dial(0, 1)
+	accepted := make(chan error)
+	go func() {
+		// The first call accepts the connection from node 0
+		lis.Accept()
+		_, err := lis.Accept()
+		accepted <- err
+	}()
+	read := make(chan error)
+	go func() {
+		_, err := client.Read(make([]byte, 1))
+		read <- err
+	}()
+	waitForRead(t, client)
+
+	nm.Close()
+	if err := <-accepted; !errors.Is(err, net.ErrClosed) {
+		t.Errorf("Expected Accept to return when the network is closed. Got: %v", err)
+	}
+	if err := <-read; !errors.Is(err, net.ErrClosed) {
+		t.Errorf("Expected Read to return when the network is closed. Got: %v", err)
+	}
+	if _, err := io.WriteString(client, "a"); err == nil {
+		t.Errorf("Expected writes to fail when the network is closed")
+	}
+	if len(sch.eventStack) != 0 {
+		t.Errorf("Expected no events to be added when the network is closed. Got: %v", sch.eventStack)
+	}
+}
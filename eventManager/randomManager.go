@@ -0,0 +1,169 @@
+package eventManager
+
+import (
+	"fmt"
+	"gomc/event"
+	"math/rand"
+	"sync"
+)
+
+// An Event Manager that provides controlled randomness to nodes.
+//
+// Each node gets a RandomSource, which implements rand.Source.
+// Each draw from the source is a nondeterministic choice made by the scheduler, and is recorded in the run as a RandomEvent so that the run can be replayed.
+// The node is paused while the choice is made, like when using the SleepManager.
+//
+// When exploring, the scheduler chooses between domain outcomes of each draw.
+// The outcomes are encoded so that rand.New(source).Intn(n) and Int31n(n) return the chosen outcome when n is at least domain.
+// The domain should therefore be the largest n used, to avoid exploring outcomes that give the same value.
+// Other methods of rand.Rand, e.g. Float64 and Shuffle, do not explore meaningful values.
+//
+// When seeded, each draw has a single outcome decided by a pseudo random generator seeded per run.
+//
+// A new RandomManager must be created for each run.
+type RandomManager struct {
+	sync.Mutex
+
+	ea      EventAdder
+	nextEvt func(error, int)
+
+	domain int
+	seed   int64
+	seeded bool
+
+	sources map[int]*RandomSource
+}
+
+// Create a RandomManager where the scheduler explores domain outcomes of each draw
+//
+// Panics if domain is less than 1, since each draw must have at least one outcome.
+func NewRandomManager(sp SimulationParameters, domain int) *RandomManager {
+	if domain < 1 {
+		panic(fmt.Errorf("RandomManager: domain must be at least 1. Got: %v", domain))
+	}
+	return &RandomManager{
+		ea:      sp.EventAdder,
+		nextEvt: sp.NextEvt,
+		domain:  domain,
+		sources: make(map[int]*RandomSource),
+	}
+}
+
+// Create a RandomManager where the outcome of each draw is decided by a pseudo random generator seeded with seed.
+//
+// The generator of each node is seeded with the seed and the id of the node, so all runs with the same seed draw the same values.
+func NewSeededRandomManager(sp SimulationParameters, seed int64) *RandomManager {
+	return &RandomManager{
+		ea:      sp.EventAdder,
+		nextEvt: sp.NextEvt,
+		seed:    seed,
+		seeded:  true,
+		sources: make(map[int]*RandomSource),
+	}
+}
+
+// Returns the source of randomness of the node with the provided id.
+//
+// The same source is returned each time the method is called with the same id.
+func (rm *RandomManager) Source(id int) *RandomSource {
+	rm.Lock()
+	defer rm.Unlock()
+	if src, ok := rm.sources[id]; ok {
+		return src
+	}
+	src := &RandomSource{rm: rm, node: id}
+	if rm.seeded {
+		src.rand = rand.New(rand.NewSource(rm.seed + int64(id)))
+	}
+	rm.sources[id] = src
+	return src
+}
+
+// Encode the outcome so that Intn(n) and Int31n(n) of rand.Rand returns it when n is larger than the outcome.
+//
+// Int31 uses the upper bits and masking uses the lower bits.
+func encodeOutcome(outcome int) int64 {
+	return int64(outcome)<<32 | int64(outcome)
+}
+
+// A source of randomness where each draw is controlled by the simulator.
+//
+// Implements the rand.Source interface.
+type RandomSource struct {
+	sync.Mutex
+
+	rm   *RandomManager
+	node int
+	// The number of draws from the source
+	draws int
+	// The generator used when seeded
+	rand *rand.Rand
+}
+
+// Returns the outcomes of the next draw
+func (rs *RandomSource) outcomes() []int64 {
+	if rs.rand != nil {
+		return []int64{rs.rand.Int63()}
+	}
+	outcomes := make([]int64, rs.rm.domain)
+	for i := range outcomes {
+		outcomes[i] = encodeOutcome(i)
+	}
+	return outcomes
+}
+
+// Draw a value.
+//
+// Adds a RandomEvent for each outcome of the draw and waits until one of them is executed.
+// The events of the other outcomes are removed if the scheduler supports removing events. Otherwise they have no effect.
+func (rs *RandomSource) Int63() int64 {
+	rs.Lock()
+	draw := rs.draws
+	rs.draws++
+	outcomes := rs.outcomes()
+	rs.Unlock()
+
+	var (
+		mu     sync.Mutex
+		chosen bool
+	)
+	valueChan := make(chan int64)
+	choose := func(v int64) bool {
+		mu.Lock()
+		if chosen {
+			mu.Unlock()
+			return false
+		}
+		chosen = true
+		mu.Unlock()
+		valueChan <- v
+		return true
+	}
+
+	events := make([]event.RandomEvent, len(outcomes))
+	for i, v := range outcomes {
+		events[i] = event.NewRandomEvent(rs.node, draw, v, choose)
+		rs.rm.ea.AddEvent(events[i])
+	}
+	// Inform the simulator that the node is waiting for the outcome of the draw
+	rs.rm.nextEvt(nil, rs.node)
+
+	v := <-valueChan
+	if r, ok := rs.rm.ea.(EventRemover); ok {
+		for _, evt := range events {
+			r.RemoveEvent(evt.Id())
+		}
+	}
+	return v
+}
+
+// Seed the source.
+//
+// Reseeds the generator if the RandomManager is seeded. Otherwise the call has no effect.
+func (rs *RandomSource) Seed(seed int64) {
+	rs.Lock()
+	defer rs.Unlock()
+	if rs.rand != nil {
+		rs.rand.Seed(seed)
+	}
+}
@@ -0,0 +1,73 @@
+package eventManager
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestRandomManagerExplore(t *testing.T) {
+	sch := NewMockScheduler()
+	waiting := make(chan bool)
+	rm := NewRandomManager(SimulationParameters{
+		EventAdder: removingScheduler{sch},
+		NextEvt:    func(err error, id int) { waiting <- true },
+	}, 3)
+	r := rand.New(rm.Source(0))
+
+	result := make(chan int)
+	go func() { result <- r.Intn(3) }()
+	<-waiting
+	if len(sch.eventStack) != 3 {
+		t.Fatalf("Expected an event for each outcome. Got: %v", sch.eventStack)
+	}
+
+	// Choose the outcome 1
+	evt := sch.eventStack[1]
+	errorChan := make(chan error, 1)
+	go evt.Execute(nil, errorChan)
+	if v := <-result; v != 1 {
+		t.Errorf("Expected the chosen outcome to be drawn. Got: %v", v)
+	}
+	if len(sch.eventStack) != 0 {
+		t.Errorf("Expected the other outcomes to be removed. Got: %v", sch.eventStack)
+	}
+	if evt.Id() != "Random 0-0 4294967297" {
+		t.Errorf("Unexpected id of the outcome. Got: %v", evt.Id())
+	}
+}
+
+func TestRandomManagerSeeded(t *testing.T) {
+	sch := NewMockScheduler()
+	waiting := make(chan bool)
+	rm := NewSeededRandomManager(SimulationParameters{
+		EventAdder: sch,
+		NextEvt:    func(err error, id int) { waiting <- true },
+	}, 10)
+
+	result := make(chan int64)
+	go func() { result <- rm.Source(1).Int63() }()
+	<-waiting
+	if len(sch.eventStack) != 1 {
+		t.Fatalf("Expected a single outcome. Got: %v", sch.eventStack)
+	}
+	evt, _ := sch.GetEvent()
+	go evt.Execute(nil, make(chan error, 1))
+
+	expected := rand.New(rand.NewSource(11)).Int63()
+	if v := <-result; v != expected {
+		t.Errorf("Expected the value of the seeded generator. Got: %v. Expected: %v", v, expected)
+	}
+}
+
+func TestRandomManagerInvalidDomain(t *testing.T) {
+	for _, domain := range []int{0, -1} {
+		func() {
+			defer func() {
+				if p := recover(); p == nil {
+					t.Errorf("Expected NewRandomManager to panic with domain %v", domain)
+				}
+			}()
+			NewRandomManager(SimulationParameters{EventAdder: NewMockScheduler()}, domain)
+		}()
+	}
+}
@@ -8,13 +8,28 @@ import (
 //
 // Represent a message as a call to a message handler method on the target node.
 // When the message arrived the method is called with the parameters.
+//
+// If the SimulationParameters contain NetworkFaults, messages can be lost or duplicated.
+// If the SimulationParameters contain a MessageInterceptor, the interceptor is given the chance to handle each message first.
 type Sender struct {
-	ea EventAdder
+	ea          EventAdder
+	faults      *NetworkFaults
+	interceptor MessageInterceptor
+}
+
+// Intercepts messages sent using the Sender, e.g. to inject faults in the messages sent by some nodes.
+//
+// Only the Sender uses the MessageInterceptor. Messages sent using the other Event Managers are not intercepted.
+type MessageInterceptor interface {
+	// Add the message to the EventAdder, possibly together with alternatives of the message.
+	//
+	// Returns false if the message is not intercepted and should be sent as normal.
+	InterceptMessage(ea EventAdder, msg event.MessageHandlerEvent) bool
 }
 
 // Create a new Sender with the provided EventAdder
 func NewSender(sp SimulationParameters) *Sender {
-	return &Sender{ea: sp.EventAdder}
+	return &Sender{ea: sp.EventAdder, faults: sp.NetworkFaults, interceptor: sp.MessageInterceptor}
 }
 
 // Creates a send function that creates an event representing the message to be sent to the target node
@@ -27,6 +42,14 @@ func NewSender(sp SimulationParameters) *Sender {
 // params is the parameters that will be passed to the message handler method.
 func (s *Sender) SendFunc(id int) func(int, string, ...any) {
 	return func(to int, msgType string, params ...any) {
-		s.ea.AddEvent(event.NewMessageHandlerEvent(id, to, msgType, params...))
+		evt := event.NewMessageHandlerEvent(id, to, msgType, params...)
+		if s.interceptor != nil && s.interceptor.InterceptMessage(s.ea, evt) {
+			return
+		}
+		if s.faults == nil {
+			s.ea.AddEvent(evt)
+			return
+		}
+		s.faults.addMessage(s.ea, evt, func() {}, func() { s.ea.AddEvent(evt) })
 	}
 }
@@ -1,5 +1,7 @@
 package eventManager
 
+import "sync"
+
 // TODO: Update thesis to reflect the move of SimulationParameters into eventManager package
 
 // Stores the SimulationParameters used in the specific run of the simulation
@@ -10,8 +12,58 @@ type SimulationParameters struct {
 	NextEvt func(error, int)
 
 	// Used by node to subscribe to status updates from the failure detector
+	//
+	// Several callbacks can be subscribed for the same node, e.g. by the node and by the Event Managers it uses
 	CrashSubscribe func(NodeId int, callback func(id int, status bool))
 
+	// Used by node to subscribe to leader changes from the leader oracle
+	//
+	// Is nil if the failure manager does not provide a leader oracle
+	LeaderSubscribe func(NodeId int, callback func(leader int))
+
 	// Add events to the EventAdder used by the simulation
 	EventAdder EventAdder
+
+	// The clock nodes should use to read the time
+	//
+	// Is a SimulatedClock when simulating and a RealClock when running
+	Clock Clock
+
+	// The model of network faults used by the Event Managers sending messages
+	//
+	// Is nil if the network is reliable
+	NetworkFaults *NetworkFaults
+
+	// Intercepts the messages sent using the Sender
+	//
+	// Messages sent using the other Event Managers, e.g. the TypedSender, are not intercepted
+	//
+	// Is nil if the failure manager does not intercept messages
+	MessageInterceptor MessageInterceptor
+}
+
+// Create a function subscribing to status updates that allows several callbacks to be subscribed for the same node.
+//
+// subscribe is the subscribe function of the failure detector, which keeps a single callback for each node.
+// The first time a node subscribes, a callback calling all the callbacks subscribed for the node in the order they were subscribed is subscribed using subscribe.
+func CombineCrashSubscriptions(subscribe func(int, func(int, bool))) func(int, func(int, bool)) {
+	lock := sync.Mutex{}
+	callbacks := make(map[int][]func(int, bool))
+	return func(nodeId int, callback func(int, bool)) {
+		lock.Lock()
+		subscribed := len(callbacks[nodeId]) > 0
+		callbacks[nodeId] = append(callbacks[nodeId], callback)
+		lock.Unlock()
+		if subscribed {
+			return
+		}
+		subscribe(nodeId, func(id int, status bool) {
+			lock.Lock()
+			fs := append([]func(int, bool){}, callbacks[nodeId]...)
+			lock.Unlock()
+			for _, f := range fs {
+				f(id, status)
+			}
+		})
+	}
 }
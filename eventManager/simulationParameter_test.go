@@ -0,0 +1,22 @@
+package eventManager
+
+import (
+	"testing"
+)
+
+func TestCombineCrashSubscriptions(t *testing.T) {
+	subscribed := map[int]func(int, bool){}
+	subscribe := CombineCrashSubscriptions(func(id int, callback func(int, bool)) { subscribed[id] = callback })
+
+	calls := []string{}
+	subscribe(0, func(id int, status bool) { calls = append(calls, "node") })
+	subscribe(0, func(id int, status bool) { calls = append(calls, "manager") })
+	if len(subscribed) != 1 {
+		t.Fatalf("Expected a single subscription to the failure detector. Got: %v", subscribed)
+	}
+
+	subscribed[0](1, false)
+	if len(calls) != 2 || calls[0] != "node" || calls[1] != "manager" {
+		t.Errorf("Expected both callbacks to be called in the order they were subscribed. Got: %v", calls)
+	}
+}
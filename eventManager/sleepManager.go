@@ -8,9 +8,12 @@ import (
 )
 
 // An EventManager that is used to set timeouts
+//
+// If the SimulationParameters contain a SimulatedClock, the clock advances by the duration of the timeout when the timeout expires.
 type SleepManager struct {
 	ea      EventAdder
 	nextEvt func(error, int)
+	clock   Clock
 }
 
 // Create a SleepManager with the provided EventAdder and nextEvent function
@@ -18,6 +21,7 @@ func NewSleepManager(sp SimulationParameters) *SleepManager {
 	return &SleepManager{
 		ea:      sp.EventAdder,
 		nextEvt: sp.NextEvt,
+		clock:   sp.Clock,
 	}
 }
 
@@ -26,10 +30,11 @@ func NewSleepManager(sp SimulationParameters) *SleepManager {
 // Should create a new sleep function for each node.
 //
 // The returned sleep function imitates the signature of the time.Sleep function.
-// The provided duration is ignored.
+// The provided duration is only used to advance the simulated clock.
 // It is assumed that at most one timeout is active at each node at teh same time.
 func (sm *SleepManager) SleepFunc(id int) func(time.Duration) {
-	return func(_ time.Duration) {
+	return func(d time.Duration) {
+		wakeup := deadline(sm.clock, d)
 		sleepChan := make(chan time.Time)
 		_, file, line, _ := runtime.Caller(1)
 		evt := event.NewSleepEvent(fmt.Sprintf("File: %v, Line: %v", file, line), id, sleepChan)
@@ -40,5 +45,6 @@ func (sm *SleepManager) SleepFunc(id int) func(time.Duration) {
 
 		// Wait until the event is executed before returning
 		<-sleepChan
+		advanceTo(sm.clock, wakeup)
 	}
 }
@@ -0,0 +1,73 @@
+package eventManager
+
+import (
+	"gomc/event"
+)
+
+// A message that can be lost by the network
+type droppableMessage interface {
+	event.MessageEvent
+	Drop()
+}
+
+// The events of a synchronous call, where the calling node blocks until the result of the call is delivered.
+//
+// R is the result of the call that is returned to the calling node.
+type syncCall[R any] struct {
+	ea      EventAdder
+	nextEvt func(error, int)
+	faults  *NetworkFaults
+
+	// The id of the calling node and the id of the server
+	from, to int
+
+	// Create the event delivering the request to the server.
+	// deliver sends the request to the server, and drop is called instead if the request is lost.
+	request func(deliver func(), drop func()) droppableMessage
+	// Create the event delivering the result to the calling node.
+	// from is the sender of the result, respond unblocks the calling node and drop is called instead if the result is lost.
+	response func(from int, res R, respond func(), drop func()) droppableMessage
+	// Send the request to the server and return the result when the server has handled it
+	send func() R
+	// Send a duplicate of the request to the server. The result is ignored
+	resend func()
+	// The result returned to the calling node if the request or the response is lost
+	lost R
+}
+
+// Make the call and block the calling node until the result has been delivered.
+//
+// The request and the response are delivered by separate events, and both can be lost by the NetworkFaults or by a partition of the network.
+// If either is lost the lost result is delivered by an event from the calling node to itself, since the calling node detects the loss locally.
+// The response to a duplicated request is ignored. The response can not be duplicated, since the calling node only receives one result and a duplicate would have no effect.
+// The call must be made while the node is executing an event, and not in a separate goroutine.
+func (sc syncCall[R]) call() R {
+	result := make(chan R)
+	lost := func() {
+		sc.ea.AddEvent(sc.response(sc.from, sc.lost, func() { result <- sc.lost }, func() {}))
+	}
+	respond := func(res R) {
+		sc.addMessage(sc.response(sc.to, res, func() { result <- res }, lost), nil)
+	}
+	sc.addMessage(sc.request(func() { respond(sc.send()) }, lost), func() {
+		sc.ea.AddEvent(sc.request(sc.resend, func() {}))
+	})
+
+	// Inform the simulator that the node is waiting for the result
+	// The simulator can now proceed with scheduling events
+	sc.nextEvt(nil, sc.from)
+
+	// Wait until the result has been delivered
+	return <-result
+}
+
+// Add the message, together with the alternatives of losing and duplicating it if the call is made on an unreliable network
+//
+// If onDuplicate is nil the message can only be lost.
+func (sc syncCall[R]) addMessage(msg droppableMessage, onDuplicate func()) {
+	if sc.faults == nil {
+		sc.ea.AddEvent(msg)
+	} else {
+		sc.faults.addMessage(sc.ea, msg, msg.Drop, onDuplicate)
+	}
+}
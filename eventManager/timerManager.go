@@ -0,0 +1,215 @@
+package eventManager
+
+import (
+	"gomc/event"
+	"sync"
+	"time"
+)
+
+// An Event Manager that is used to create timers.
+//
+// Mimics the time.AfterFunc, time.NewTimer and time.NewTicker functions.
+// Each expiration of a timer is an event, so that the simulator controls when timers expire.
+// Nodes can have several active timers at the same time.
+// Stopping a timer removes the pending event from the scheduler if it supports removing events. Otherwise the event has no effect when it is executed.
+// If the SimulationParameters contain a SimulatedClock, the clock advances to the deadline of a timer when it expires.
+// Durations are otherwise ignored, and the simulator can let the timers expire in any order.
+//
+// A new TimerManager must be created for each run, so that the ids of the timers are stable across runs.
+type TimerManager struct {
+	sync.Mutex
+
+	ea    EventAdder
+	clock Clock
+	// The number of timers created by each node
+	timers map[int]int
+}
+
+// Create a TimerManager with the provided EventAdder
+func NewTimerManager(sp SimulationParameters) *TimerManager {
+	return &TimerManager{
+		ea:     sp.EventAdder,
+		clock:  sp.Clock,
+		timers: make(map[int]int),
+	}
+}
+
+// Create a timer on the node with the provided id that calls f when it expires.
+//
+// Analogous to time.AfterFunc. f is called while executing the event.
+func (tm *TimerManager) AfterFunc(id int, d time.Duration, f func()) *Timer {
+	t := tm.newTimer(id, false)
+	t.f = f
+	t.start(d)
+	return t
+}
+
+// Create a timer on the node with the provided id that sends the current time on its channel when it expires.
+//
+// Analogous to time.NewTimer.
+// The value is sent on the channel while executing the event, but is received by the node outside the control of the simulator.
+// AfterFunc should be preferred when the handling of the timer must be part of the event.
+func (tm *TimerManager) NewTimer(id int, d time.Duration) *Timer {
+	t := tm.newTimer(id, false)
+	t.start(d)
+	return t
+}
+
+// Create a ticker on the node with the provided id that sends the current time on its channel each time it ticks.
+//
+// Analogous to time.NewTicker. The next tick is scheduled when the previous tick has been executed.
+func (tm *TimerManager) NewTicker(id int, d time.Duration) *Ticker {
+	t := tm.newTimer(id, true)
+	t.start(d)
+	return &Ticker{C: t.C, t: t}
+}
+
+// Create a timer with the next sequence number of the node
+func (tm *TimerManager) newTimer(id int, periodic bool) *Timer {
+	tm.Lock()
+	seq := tm.timers[id]
+	tm.timers[id]++
+	tm.Unlock()
+
+	c := make(chan time.Time, 1)
+	return &Timer{
+		C:        c,
+		c:        c,
+		tm:       tm,
+		node:     id,
+		seq:      seq,
+		periodic: periodic,
+	}
+}
+
+// A timer created by the TimerManager.
+//
+// Analogous to time.Timer.
+type Timer struct {
+	// The channel on which the time is sent when the timer expires. Not used by timers created by AfterFunc.
+	C <-chan time.Time
+	c chan time.Time
+
+	tm       *TimerManager
+	node     int
+	seq      int
+	periodic bool
+	f        func()
+
+	// Guarded by the lock of the TimerManager
+	active     bool
+	generation int
+	duration   time.Duration
+	deadline   time.Time
+	pending    event.EventId
+}
+
+// Schedule the expiration of the timer
+func (t *Timer) start(d time.Duration) {
+	t.tm.Lock()
+	evt := t.schedule(d)
+	t.tm.Unlock()
+	t.tm.ea.AddEvent(evt)
+}
+
+// Create the next event of the timer.
+//
+// Must be called while holding the lock of the TimerManager.
+func (t *Timer) schedule(d time.Duration) event.TimerEvent {
+	t.active = true
+	t.generation++
+	t.duration = d
+	t.deadline = deadline(t.tm.clock, d)
+	generation := t.generation
+	evt := event.NewTimerEvent(t.node, t.seq, generation, d, func() { t.fire(generation) })
+	t.pending = evt.Id()
+	return evt
+}
+
+// Expire the timer.
+//
+// Has no effect if the timer has been stopped or reset since the event was created.
+func (t *Timer) fire(generation int) {
+	t.tm.Lock()
+	if !t.active || generation != t.generation {
+		t.tm.Unlock()
+		return
+	}
+	t.active = false
+	advanceTo(t.tm.clock, t.deadline)
+	var next *event.TimerEvent
+	if t.periodic {
+		evt := t.schedule(t.duration)
+		next = &evt
+	}
+	t.tm.Unlock()
+
+	if next != nil {
+		t.tm.ea.AddEvent(*next)
+	}
+	if t.f != nil {
+		t.f()
+		return
+	}
+	// Drop the value if the previous value has not been received, like time.Timer and time.Ticker
+	now := time.Time{}
+	if t.tm.clock != nil {
+		now = t.tm.clock.Now()
+	}
+	select {
+	case t.c <- now:
+	default:
+	}
+}
+
+// Stop the timer.
+//
+// Analogous to time.Timer.Stop.
+// Returns true if the call stops the timer, false if the timer has already expired or been stopped.
+func (t *Timer) Stop() bool {
+	t.tm.Lock()
+	wasActive := t.active
+	t.active = false
+	pending := t.pending
+	t.tm.Unlock()
+
+	if wasActive {
+		if r, ok := t.tm.ea.(EventRemover); ok {
+			r.RemoveEvent(pending)
+		}
+	}
+	return wasActive
+}
+
+// Change the timer to expire after the duration d.
+//
+// Analogous to time.Timer.Reset.
+// Returns true if the timer had been active, false if the timer had expired or been stopped.
+func (t *Timer) Reset(d time.Duration) bool {
+	wasActive := t.Stop()
+	t.start(d)
+	return wasActive
+}
+
+// A ticker created by the TimerManager.
+//
+// Analogous to time.Ticker.
+type Ticker struct {
+	// The channel on which the ticks are delivered
+	C <-chan time.Time
+	t *Timer
+}
+
+// Turn off the ticker. No more ticks will be sent.
+//
+// Analogous to time.Ticker.Stop.
+func (t *Ticker) Stop() {
+	t.t.Stop()
+}
+
+// Stop the ticker and reset its period to the duration d.
+//
+// Analogous to time.Ticker.Reset.
+func (t *Ticker) Reset(d time.Duration) {
+	t.t.Reset(d)
+}
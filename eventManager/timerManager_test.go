@@ -0,0 +1,111 @@
+package eventManager
+
+import (
+	"gomc/event"
+	"testing"
+	"time"
+)
+
+// A MockScheduler that supports removing events
+type removingScheduler struct {
+	*MockScheduler
+}
+
+func (rs removingScheduler) RemoveEvent(id event.EventId) bool {
+	for i, evt := range rs.eventStack {
+		if evt.Id() == id {
+			rs.eventStack = append(rs.eventStack[:i], rs.eventStack[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// Execute the last added event
+func executeLast(t *testing.T, sch *MockScheduler) event.Event {
+	t.Helper()
+	evt, _ := sch.GetEvent()
+	errorChan := make(chan error, 1)
+	evt.Execute(nil, errorChan)
+	if err := <-errorChan; err != nil {
+		t.Fatalf("Unexpected error executing %v: %v", evt, err)
+	}
+	return evt
+}
+
+func TestTimerManagerAfterFunc(t *testing.T) {
+	sch := NewMockScheduler()
+	tm := NewTimerManager(SimulationParameters{EventAdder: removingScheduler{sch}})
+
+	fired := []int{}
+	tm.AfterFunc(0, time.Second, func() { fired = append(fired, 0) })
+	t1 := tm.AfterFunc(0, time.Second, func() { fired = append(fired, 1) })
+	if len(sch.eventStack) != 2 {
+		t.Fatalf("Expected an event for each timer. Got: %v", sch.eventStack)
+	}
+	if sch.eventStack[0].Id() != "Timer 0-0-1" || sch.eventStack[1].Id() != "Timer 0-1-1" {
+		t.Errorf("Unexpected timer ids. Got: %v", sch.eventStack)
+	}
+
+	if !t1.Stop() {
+		t.Errorf("Expected Stop to stop the active timer")
+	}
+	if len(sch.eventStack) != 1 {
+		t.Fatalf("Expected the event of the stopped timer to be removed. Got: %v", sch.eventStack)
+	}
+	if t1.Stop() {
+		t.Errorf("Did not expect Stop to stop a stopped timer")
+	}
+
+	executeLast(t, sch)
+	if len(fired) != 1 || fired[0] != 0 {
+		t.Errorf("Expected only the first timer to fire. Got: %v", fired)
+	}
+
+	// Restart the stopped timer with a new id
+	if t1.Reset(time.Second) {
+		t.Errorf("Did not expect Reset to report the stopped timer as active")
+	}
+	if evt := executeLast(t, sch); evt.Id() != "Timer 0-1-2" {
+		t.Errorf("Expected a new event for the reset timer. Got: %v", evt.Id())
+	}
+	if len(fired) != 2 || fired[1] != 1 {
+		t.Errorf("Expected the reset timer to fire. Got: %v", fired)
+	}
+}
+
+func TestTimerManagerWithoutRemove(t *testing.T) {
+	sch := NewMockScheduler()
+	tm := NewTimerManager(SimulationParameters{EventAdder: sch})
+
+	timer := tm.NewTimer(0, time.Second)
+	timer.Stop()
+	// The event can not be removed, but has no effect
+	executeLast(t, sch)
+	select {
+	case <-timer.C:
+		t.Errorf("Did not expect the stopped timer to fire")
+	default:
+	}
+}
+
+func TestTimerManagerTicker(t *testing.T) {
+	sch := NewMockScheduler()
+	tm := NewTimerManager(SimulationParameters{EventAdder: removingScheduler{sch}})
+
+	ticker := tm.NewTicker(1, time.Second)
+	for i := 1; i <= 2; i++ {
+		evt := executeLast(t, sch)
+		if expected := event.EventId("Timer 1-0-" + string(rune('0'+i))); evt.Id() != expected {
+			t.Errorf("Unexpected tick. Got: %v. Expected: %v", evt.Id(), expected)
+		}
+		<-ticker.C
+	}
+	if len(sch.eventStack) != 1 {
+		t.Fatalf("Expected the next tick to be scheduled. Got: %v", sch.eventStack)
+	}
+	ticker.Stop()
+	if len(sch.eventStack) != 0 {
+		t.Errorf("Expected the next tick to be removed. Got: %v", sch.eventStack)
+	}
+}
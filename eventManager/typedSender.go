@@ -0,0 +1,88 @@
+package eventManager
+
+import (
+	"errors"
+	"fmt"
+	"gomc/event"
+	"reflect"
+)
+
+// An Event Manager used to send typed messages between nodes of type T
+//
+// Messages of type M are delivered by calling a handler registered for the message type.
+// Unlike the Sender, the handlers are type checked when they are registered, and no reflection is used when messages are sent or delivered.
+// All handlers must be registered before messages are sent.
+//
+// If the SimulationParameters contain NetworkFaults, messages can be lost or duplicated.
+// The MessageInterceptor of the SimulationParameters is not used, since it intercepts MessageHandlerEvents.
+// Messages sent by Byzantine nodes using the TypedSender are therefore delivered as normal by the ByzantineFailureManager.
+type TypedSender[T, M any] struct {
+	ea       EventAdder
+	faults   *NetworkFaults
+	handlers map[string]func(*T, int, M)
+}
+
+// Create a new TypedSender with the provided EventAdder
+func NewTypedSender[T, M any](sp SimulationParameters) *TypedSender[T, M] {
+	return &TypedSender[T, M]{
+		ea:       sp.EventAdder,
+		faults:   sp.NetworkFaults,
+		handlers: make(map[string]func(*T, int, M)),
+	}
+}
+
+// Register the handler that is called when a message of type msgType arrives at a node.
+//
+// The handler is called with the receiving node, the id of the sending node and the message.
+// Returns an error if the handler is nil or if a handler is already registered for the message type.
+func (ts *TypedSender[T, M]) Handle(msgType string, handler func(*T, int, M)) error {
+	if handler == nil {
+		return fmt.Errorf("TypedSender: The handler of message type %v is nil", msgType)
+	}
+	if _, ok := ts.handlers[msgType]; ok {
+		return fmt.Errorf("TypedSender: A handler is already registered for message type %v", msgType)
+	}
+	ts.handlers[msgType] = handler
+	return nil
+}
+
+// Register the method of T with the given name as the handler of messages of type name.
+//
+// The method must have the signature func(from int, msg M).
+// The signature is checked once when the method is registered.
+// Returns an error if T has no such method or if the method has a different signature.
+func (ts *TypedSender[T, M]) HandleMethod(name string) error {
+	method, ok := reflect.TypeOf((*T)(nil)).MethodByName(name)
+	if !ok {
+		return fmt.Errorf("TypedSender: %v has no method %v", reflect.TypeOf((*T)(nil)), name)
+	}
+	handler, ok := method.Func.Interface().(func(*T, int, M))
+	if !ok {
+		return fmt.Errorf("TypedSender: Method %v has type %v. Expected: %v", name, method.Type, reflect.TypeOf(func(*T, int, M) {}))
+	}
+	return ts.Handle(name, handler)
+}
+
+// Creates a send function that creates an event representing the message to be sent to the target node
+// The SendFunc is called with the id of the node that will send the messages.
+// Should create a new send function for each node.
+//
+// The returned send function is used to represent mechanism that sends a message to a node
+// to represent the target of the message
+// msgType is the type of the message, which determines the handler that is called when the message arrive.
+// msg is the message that is passed to the handler.
+// The send function panics if no handler is registered for msgType.
+func (ts *TypedSender[T, M]) SendFunc(id int) func(int, string, M) {
+	return func(to int, msgType string, msg M) {
+		handler, ok := ts.handlers[msgType]
+		if !ok {
+			panic(errors.New("TypedSender: No handler registered for message type " + msgType))
+		}
+		evt := event.NewTypedMessageEvent(id, to, msgType, msg, handler)
+		if ts.faults == nil {
+			ts.ea.AddEvent(evt)
+			return
+		}
+		ts.faults.addMessage(ts.ea, evt, func() {}, func() { ts.ea.AddEvent(evt) })
+	}
+}
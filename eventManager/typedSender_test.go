@@ -0,0 +1,73 @@
+package eventManager
+
+import (
+	"gomc/event"
+	"testing"
+)
+
+type typedNode struct {
+	received []string
+}
+
+func (n *typedNode) Foo(from int, msg string) {
+	n.received = append(n.received, msg)
+}
+
+func (n *typedNode) Bar(msg string) {}
+
+func TestTypedSender(t *testing.T) {
+	sch := NewMockScheduler()
+	sender := NewTypedSender[typedNode, string](SimulationParameters{
+		EventAdder: sch,
+	})
+	if err := sender.HandleMethod("Foo"); err != nil {
+		t.Fatalf("Unexpected error registering handler: %v", err)
+	}
+	send := sender.SendFunc(0)
+	send(1, "Foo", "Hello")
+
+	out, _ := sch.GetEvent()
+	expected := event.NewMessageHandlerEvent(0, 1, "Foo", "Hello")
+	if out.Id() != expected.Id() {
+		t.Fatalf("Expected the typed message to have the same id as a message handler event. Got: %v. Expected: %v", out.Id(), expected.Id())
+	}
+
+	node := &typedNode{}
+	errorChan := make(chan error, 1)
+	out.Execute(node, errorChan)
+	if err := <-errorChan; err != nil {
+		t.Fatalf("Unexpected error executing the message: %v", err)
+	}
+	if len(node.received) != 1 || node.received[0] != "Hello" {
+		t.Errorf("Expected the message to be delivered. Got: %v", node.received)
+	}
+}
+
+func TestTypedSenderHandlerSignature(t *testing.T) {
+	sender := NewTypedSender[typedNode, string](SimulationParameters{})
+	if err := sender.HandleMethod("Baz"); err == nil {
+		t.Errorf("Expected an error registering a method that does not exist")
+	}
+	if err := sender.HandleMethod("Bar"); err == nil {
+		t.Errorf("Expected an error registering a method with the wrong signature")
+	}
+	if err := sender.Handle("Foo", nil); err == nil {
+		t.Errorf("Expected an error registering a nil handler")
+	}
+	if err := sender.Handle("Foo", (*typedNode).Foo); err != nil {
+		t.Fatalf("Unexpected error registering handler: %v", err)
+	}
+	if err := sender.HandleMethod("Foo"); err == nil {
+		t.Errorf("Expected an error registering a handler twice")
+	}
+}
+
+func TestTypedSenderUnknownType(t *testing.T) {
+	sender := NewTypedSender[typedNode, string](SimulationParameters{EventAdder: NewMockScheduler()})
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Expected sending a message without a handler to panic")
+		}
+	}()
+	sender.SendFunc(0)(1, "Foo", "Hello")
+}
@@ -1,8 +1,6 @@
 package main
 
 import (
-	"bytes"
-	"encoding/json"
 	"os"
 	"testing"
 
@@ -11,6 +9,7 @@ import (
 	"gomc"
 	"gomc/checking"
 	"gomc/eventManager"
+	"gomc/gomctest"
 )
 
 type state struct {
@@ -78,7 +77,13 @@ func TestConsensus(t *testing.T) {
 	)
 
 	nodeIds := []int{1, 2, 3}
-	resp := sim.Run(
+	requests := gomc.WithRequests(
+		gomc.NewRequest(1, "Propose", Value[int]{1}),
+		gomc.NewRequest(2, "Propose", Value[int]{2}),
+		gomc.NewRequest(3, "Propose", Value[int]{3}),
+	)
+	gomctest.Check(t, sim,
+		gomc.ReplayConfig(nodeIds, []int{1}, requests, 0, predicates...),
 		gomc.InitSingleNode(nodeIds,
 			func(id int, sp eventManager.SimulationParameters) *HierarchicalConsensus[int] {
 				send := eventManager.NewSender(sp)
@@ -91,11 +96,7 @@ func TestConsensus(t *testing.T) {
 				return node
 			},
 		),
-		gomc.WithRequests(
-			gomc.NewRequest(1, "Propose", Value[int]{1}),
-			gomc.NewRequest(2, "Propose", Value[int]{2}),
-			gomc.NewRequest(3, "Propose", Value[int]{3}),
-		),
+		requests,
 		gomc.WithPredicateChecker(predicates...),
 		gomc.WithPerfectFailureManager(
 			func(t *HierarchicalConsensus[int]) { t.crashed = true },
@@ -103,11 +104,4 @@ func TestConsensus(t *testing.T) {
 		),
 		gomc.Export(os.Stdout),
 	)
-	if ok, out := resp.Response(); !ok {
-		t.Errorf("Expected no errors while checking. Got: %v", out)
-
-		var buffer bytes.Buffer
-		json.NewEncoder(&buffer).Encode(resp.Export())
-		os.WriteFile("FailedRun.txt", buffer.Bytes(), 0755)
-	}
 }
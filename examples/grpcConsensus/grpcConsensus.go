@@ -68,9 +68,12 @@ func NewGrpcConsensus(id int32, lis net.Listener, waitForSend func(int), srvOpts
 	return gc
 }
 
-func (gc *GrpcConsensus) DialServers(addrMap map[int32]string, dialOpts ...grpc.DialOption) {
+// Connect to the servers in the addrMap.
+//
+// dial is used to create the connection to each server, e.g. by calling grpc.Dial with the address.
+func (gc *GrpcConsensus) DialServers(addrMap map[int32]string, dial func(id int32, addr string) (*grpc.ClientConn, error)) {
 	for id, addr := range addrMap {
-		conn, err := grpc.Dial(addr, dialOpts...)
+		conn, err := dial(id, addr)
 		if err != nil {
 			panic(err)
 		}
@@ -1,12 +1,9 @@
 package main
 
 import (
-	"bytes"
-	"encoding/json"
 	"fmt"
 	"gomc"
-	"gomc/event"
-	"os"
+	"gomc/replay"
 	"testing"
 )
 
@@ -16,6 +13,17 @@ var addrMap = map[int32]string{
 	3: ":50002",
 }
 
+const replayPath = "FailedRun.json"
+
+var replayRequests = gomc.WithRequests(
+	gomc.NewRequest(1, "Propose", "1"),
+	gomc.NewRequest(2, "Propose", "2"),
+	gomc.NewRequest(3, "Propose", "3"),
+)
+
+// The configuration recorded in the replay artifact
+var replayConfig = gomc.ReplayConfig([]int{1, 2, 3}, []int{1}, replayRequests, 0, predicates...)
+
 func TestGrpcConsensusCreateReplay(t *testing.T) {
 	sim := gomc.PrepareSimulation(
 		gomc.WithTreeStateManager(getState, cmpState),
@@ -24,11 +32,7 @@ func TestGrpcConsensusCreateReplay(t *testing.T) {
 
 	resp := sim.Run(
 		gomc.InitNodeFunc(createNodes(addrMap)),
-		gomc.WithRequests(
-			gomc.NewRequest(1, "Propose", "1"),
-			gomc.NewRequest(2, "Propose", "2"),
-			gomc.NewRequest(3, "Propose", "3"),
-		),
+		replayRequests,
 		gomc.WithPredicateChecker(predicates...),
 		gomc.WithPerfectFailureManager(
 			func(t *GrpcConsensus) { t.Stop() }, 1,
@@ -40,41 +44,32 @@ func TestGrpcConsensusCreateReplay(t *testing.T) {
 	ok, text := resp.Response()
 	if ok {
 		t.Errorf("Expected simulation to fail. Got:\n %v", text)
-	} else {
-		var buffer bytes.Buffer
-		json.NewEncoder(&buffer).Encode(resp.Export())
-		os.WriteFile("FailedRun.txt", buffer.Bytes(), 0755)
+	} else if err := replay.New(replayConfig, resp).Save(replayPath); err != nil {
+		t.Errorf("Error while saving the replay artifact: %v", err)
 	}
 }
 
 func TestReplayConsensus(t *testing.T) {
-	in, err := os.ReadFile("FailedRun.txt")
+	artifact, err := replay.Load(replayPath, replayConfig)
 	if err != nil {
-		t.Errorf("Error while setting up test: %v", err)
+		t.Fatalf("Error while setting up test: %v", err)
 	}
-	buffer := bytes.NewBuffer(in)
-	var run []event.EventId
-	json.NewDecoder(buffer).Decode(&run)
 
 	sim := gomc.PrepareSimulation(
 		gomc.WithTreeStateManager(getState, cmpState),
-		gomc.ReplayScheduler(run),
 	)
 
 	resp := sim.Run(
 		gomc.InitNodeFunc(
 			createNodes(addrMap),
 		),
-		gomc.WithRequests(
-			gomc.NewRequest(1, "Propose", "1"),
-			gomc.NewRequest(2, "Propose", "2"),
-			gomc.NewRequest(3, "Propose", "3"),
-		),
+		replayRequests,
 		gomc.WithPredicateChecker(predicates...),
 		gomc.WithPerfectFailureManager(
 			func(t *GrpcConsensus) { t.Stop() }, 1,
 		),
 		gomc.WithStopFunctionSimulator(func(t *GrpcConsensus) { t.Stop() }),
+		gomc.ReplayArtifact(artifact),
 	)
 
 	fmt.Println()
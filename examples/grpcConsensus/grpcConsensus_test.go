@@ -1,8 +1,6 @@
 package main
 
 import (
-	"context"
-	"net"
 	"testing"
 	"time"
 
@@ -13,8 +11,6 @@ import (
 
 	"golang.org/x/exp/slices"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
-	"google.golang.org/grpc/test/bufconn"
 )
 
 var predicates = []checking.Predicate[state]{
@@ -58,20 +54,12 @@ var predicates = []checking.Predicate[state]{
 }
 
 func createNodes(addrMap map[int32]string) func(sp eventManager.SimulationParameters) map[int]*GrpcConsensus {
-	var addrToIdMap = map[string]int{}
-	for id, addr := range addrMap {
-		addrToIdMap[addr] = int(id)
-	}
 	return func(sp eventManager.SimulationParameters) map[int]*GrpcConsensus {
-		gem := eventManager.NewGrpcEventManager(addrToIdMap, sp)
-		lisMap := map[string]*bufconn.Listener{}
-		for _, addr := range addrMap {
-			lisMap[addr] = bufconn.Listen(bufSize)
-		}
+		gem := eventManager.NewGrpcEventManager(sp)
 
 		nodes := map[int]*GrpcConsensus{}
-		for id, addr := range addrMap {
-			gc := NewGrpcConsensus(id, lisMap[addr], gem.WaitForSend(int(id)))
+		for id := range addrMap {
+			gc := NewGrpcConsensus(id, gem.Listen(int(id)), gem.WaitForSend(int(id)))
 			sp.CrashSubscribe(int(id), gc.Crash)
 			nodes[int(id)] = gc
 		}
@@ -79,14 +67,13 @@ func createNodes(addrMap map[int32]string) func(sp eventManager.SimulationParame
 		for id, node := range nodes {
 			node.DialServers(
 				addrMap,
-				grpc.WithContextDialer(
-					func(ctx context.Context, s string) (net.Conn, error) {
-						return lisMap[s].DialContext(ctx)
-					},
-				),
-				grpc.WithBlock(),
-				grpc.WithTransportCredentials(insecure.NewCredentials()),
-				grpc.WithUnaryInterceptor(gem.UnaryClientControllerInterceptor(int(id))),
+				func(to int32, _ string) (*grpc.ClientConn, error) {
+					return gem.Dial(
+						int(to),
+						grpc.WithBlock(),
+						grpc.WithUnaryInterceptor(gem.UnaryClientControllerInterceptor(int(id))),
+					)
+				},
 			)
 		}
 		return nodes
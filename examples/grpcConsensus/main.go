@@ -41,13 +41,18 @@ func main() {
 	for _, node := range nodes {
 		node.DialServers(
 			addrMap,
-			grpc.WithContextDialer(
-				func(ctx context.Context, s string) (net.Conn, error) {
-					return lisMap[s].DialContext(ctx)
-				},
-			),
-			grpc.WithBlock(),
-			grpc.WithTransportCredentials(insecure.NewCredentials()),
+			func(_ int32, addr string) (*grpc.ClientConn, error) {
+				return grpc.Dial(
+					addr,
+					grpc.WithContextDialer(
+						func(ctx context.Context, s string) (net.Conn, error) {
+							return lisMap[s].DialContext(ctx)
+						},
+					),
+					grpc.WithBlock(),
+					grpc.WithTransportCredentials(insecure.NewCredentials()),
+				)
+			},
 		)
 	}
 
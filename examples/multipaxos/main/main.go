@@ -44,9 +44,14 @@ func main() {
 
 	for _, mp := range nodes {
 		mp.DialNodes(
-			grpc.WithContextDialer(dial),
-			grpc.WithBlock(),
-			grpc.WithTransportCredentials(insecure.NewCredentials()),
+			func(_ int64, addr string) (*grpc.ClientConn, error) {
+				return grpc.Dial(
+					addr,
+					grpc.WithContextDialer(dial),
+					grpc.WithBlock(),
+					grpc.WithTransportCredentials(insecure.NewCredentials()),
+				)
+			},
 		)
 	}
 
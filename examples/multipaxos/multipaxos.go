@@ -51,8 +51,11 @@ func (mp *MultiPaxos) Start(lis net.Listener, srvOpts ...grpc.ServerOption) {
 	go mp.srv.StartServer(mp, lis, srvOpts...)
 }
 
-func (mp *MultiPaxos) DialNodes(dialOpts ...grpc.DialOption) error {
-	nodes, err := mp.srv.DialNodes(mp.addrMap, dialOpts...)
+// Connect to the nodes in the address map.
+//
+// dial is used to create the connection to each node, e.g. by calling grpc.Dial with the address.
+func (mp *MultiPaxos) DialNodes(dial func(id int64, addr string) (*grpc.ClientConn, error)) error {
+	nodes, err := mp.srv.DialNodes(mp.addrMap, dial)
 	if err != nil {
 		return err
 	}
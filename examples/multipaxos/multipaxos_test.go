@@ -1,16 +1,12 @@
 package multipaxos
 
 import (
-	"context"
-	"net"
 	"os"
 	"testing"
 
 	"golang.org/x/exp/maps"
 	"golang.org/x/exp/slices"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
-	"google.golang.org/grpc/test/bufconn"
 
 	"gomc"
 	"gomc/checking"
@@ -66,7 +62,6 @@ type State struct {
 }
 
 var (
-	bufSize = 1024
 	addrMap = map[int64]string{
 		1: ":50000",
 		2: ":50001",
@@ -89,36 +84,26 @@ func cmpState(s1, s2 State) bool {
 }
 
 func InitNodes(addrMap map[int64]string) func(sp eventManager.SimulationParameters) map[int]*MultiPaxos {
-	addrToIdMap := map[string]int{}
-	for id, addr := range addrMap {
-		addrToIdMap[addr] = int(id)
-	}
-
 	return func(sp eventManager.SimulationParameters) map[int]*MultiPaxos {
-		lisMap := map[string]*bufconn.Listener{}
-		for _, addr := range addrMap {
-			lisMap[addr] = bufconn.Listen(bufSize)
-		}
-		gem := eventManager.NewGrpcEventManager(addrToIdMap, sp)
+		gem := eventManager.NewGrpcEventManager(sp)
 
 		nodes := make(map[int]*MultiPaxos)
-		for id, addr := range addrMap {
+		for id := range addrMap {
 			srv := NewMultiPaxos(id, addrMap, gem.WaitForSend(int(id)))
-			go srv.Start(lisMap[addr])
+			go srv.Start(gem.Listen(int(id)))
 			sp.CrashSubscribe(int(id), srv.proposer.leader.NodeCrash)
 			nodes[int(id)] = srv
 		}
 
 		for id, node := range nodes {
 			node.DialNodes(
-				grpc.WithUnaryInterceptor(gem.UnaryClientControllerInterceptor(id)),
-				grpc.WithContextDialer(
-					func(ctx context.Context, s string) (net.Conn, error) {
-						return lisMap[s].DialContext(ctx)
-					},
-				),
-				grpc.WithBlock(),
-				grpc.WithTransportCredentials(insecure.NewCredentials()),
+				func(to int64, _ string) (*grpc.ClientConn, error) {
+					return gem.Dial(
+						int(to),
+						grpc.WithUnaryInterceptor(gem.UnaryClientControllerInterceptor(id)),
+						grpc.WithBlock(),
+					)
+				},
 			)
 		}
 		return nodes
@@ -27,10 +27,10 @@ func (s *server) Stop() {
 	}
 }
 
-func (s *server) DialNodes(addrMap map[int64]string, dialOpts ...grpc.DialOption) (map[int64]*multipaxosClient, error) {
+func (s *server) DialNodes(addrMap map[int64]string, dial func(id int64, addr string) (*grpc.ClientConn, error)) (map[int64]*multipaxosClient, error) {
 	nodes := make(map[int64]*multipaxosClient)
 	for id, addr := range addrMap {
-		conn, err := grpc.Dial(addr, dialOpts...)
+		conn, err := dial(id, addr)
 		if err != nil {
 			return nil, err
 		}
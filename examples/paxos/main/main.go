@@ -54,9 +54,14 @@ func main() {
 
 	for _, srv := range nodes {
 		srv.DialNodes(
-			grpc.WithContextDialer(dial),
-			grpc.WithBlock(),
-			grpc.WithTransportCredentials(insecure.NewCredentials()),
+			func(_ int64, addr string) (*grpc.ClientConn, error) {
+				return grpc.Dial(
+					addr,
+					grpc.WithContextDialer(dial),
+					grpc.WithBlock(),
+					grpc.WithTransportCredentials(insecure.NewCredentials()),
+				)
+			},
 		)
 	}
 
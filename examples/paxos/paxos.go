@@ -140,10 +140,13 @@ func (p *Server) Stop() {
 	p.stopped = true
 }
 
-func (p *Server) DialNodes(dialOpts ...grpc.DialOption) error {
+// Connect to the nodes in the address map of the server.
+//
+// dial is used to create the connection to each node, e.g. by calling grpc.Dial with the address.
+func (p *Server) DialNodes(dial func(id int64, addr string) (*grpc.ClientConn, error)) error {
 	nodes := make(map[int64]*paxosClient)
 	for id, addr := range p.addrMap {
-		conn, err := grpc.Dial(addr, dialOpts...)
+		conn, err := dial(id, addr)
 		if err != nil {
 			return err
 		}
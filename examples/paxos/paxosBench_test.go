@@ -1,16 +1,12 @@
 package paxos
 
 import (
-	"context"
 	"gomc/eventManager"
-	"net"
 	"testing"
 
 	"gomc"
 
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
-	"google.golang.org/grpc/test/bufconn"
 )
 
 func BenchmarkPaxos(b *testing.B) {
@@ -35,42 +31,32 @@ func BenchmarkPaxos(b *testing.B) {
 		gomc.RandomWalkScheduler(1),
 	)
 
-	addrToIdMap := map[string]int{}
-	for id, addr := range addresses {
-		addrToIdMap[addr] = int(id)
-	}
-
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
 		sim.Run(
 			gomc.InitNodeFunc(func(sp eventManager.SimulationParameters) map[int]*Server {
-				lisMap := map[string]*bufconn.Listener{}
-				for _, addr := range addresses {
-					lisMap[addr] = bufconn.Listen(bufSize)
-				}
-				gem := eventManager.NewGrpcEventManager(addrToIdMap, sp)
+				gem := eventManager.NewGrpcEventManager(sp)
 
 				nodes := make(map[int]*Server)
-				for id, addr := range addresses {
+				for id := range addresses {
 					srv, err := NewServer(id, addresses, gem.WaitForSend(int(id)))
 					if err != nil {
 						b.Errorf("Error while starting simulation: %v", err)
 					}
-					go srv.StartServer(lisMap[addr])
+					go srv.StartServer(gem.Listen(int(id)))
 					sp.CrashSubscribe(int(id), srv.NodeCrash)
 					nodes[int(id)] = srv
 				}
 
 				for id, node := range nodes {
 					node.DialNodes(
-						grpc.WithUnaryInterceptor(gem.UnaryClientControllerInterceptor(id)),
-						grpc.WithContextDialer(
-							func(ctx context.Context, s string) (net.Conn, error) {
-								return lisMap[s].DialContext(ctx)
-							},
-						),
-						grpc.WithBlock(),
-						grpc.WithTransportCredentials(insecure.NewCredentials()),
+						func(to int64, _ string) (*grpc.ClientConn, error) {
+							return gem.Dial(
+								int(to),
+								grpc.WithUnaryInterceptor(gem.UnaryClientControllerInterceptor(id)),
+								grpc.WithBlock(),
+							)
+						},
 					)
 				}
 				return nodes
@@ -2,10 +2,8 @@ package main
 
 import (
 	"bufio"
-	"context"
 	"fmt"
 	"gomc"
-	"net"
 	"os"
 	"strconv"
 	"strings"
@@ -16,12 +14,9 @@ import (
 	"gomc/runner"
 
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
-	"google.golang.org/grpc/test/bufconn"
 )
 
 var (
-	bufSize = 1024
 	addrMap = map[int64]string{
 		1: "127.0.0.1:50000",
 		2: ":50001",
@@ -29,7 +24,6 @@ var (
 		4: ":50003",
 		5: ":50004",
 	}
-	addr2id = map[string]int{}
 )
 
 type State struct {
@@ -39,38 +33,29 @@ type State struct {
 
 func main() {
 
-	for id, addr := range addrMap {
-		addr2id[addr] = int(id)
-	}
 	r := gomc.PrepareRunner(
 		gomc.InitNodeFunc(
 			func(sp eventManager.SimulationParameters) map[int]*paxos.Server {
-				lisMap := map[string]*bufconn.Listener{}
-				for _, addr := range addrMap {
-					lisMap[addr] = bufconn.Listen(bufSize)
-				}
-
-				gem := eventManager.NewGrpcEventManager(addr2id, sp)
+				gem := eventManager.NewGrpcEventManager(sp)
 				nodes := make(map[int]*paxos.Server)
-				for id, addr := range addrMap {
+				for id := range addrMap {
 					srv, err := paxos.NewServer(id, addrMap, gem.WaitForSend(int(id)))
 					sp.CrashSubscribe(int(id), srv.NodeCrash)
 					if err != nil {
 						panic(err)
 					}
 					nodes[int(id)] = srv
-					go srv.StartServer(lisMap[addr])
+					go srv.StartServer(gem.Listen(int(id)))
 				}
 
 				for id, srv := range nodes {
 					err := srv.DialNodes(
-						grpc.WithContextDialer(
-							func(ctx context.Context, s string) (net.Conn, error) {
-								return lisMap[s].DialContext(ctx)
-							},
-						),
-						grpc.WithTransportCredentials(insecure.NewCredentials()),
-						grpc.WithUnaryInterceptor(gem.UnaryClientControllerInterceptor(id)),
+						func(to int64, _ string) (*grpc.ClientConn, error) {
+							return gem.Dial(
+								int(to),
+								grpc.WithUnaryInterceptor(gem.UnaryClientControllerInterceptor(id)),
+							)
+						},
 					)
 					if err != nil {
 						panic(err)
@@ -93,8 +78,8 @@ func main() {
 	// Store records by node id and write them to file
 	go func(c <-chan runner.Record) {
 		messages := map[int][]runner.Record{}
-		for _, id := range addr2id {
-			messages[id] = make([]runner.Record, 0)
+		for id := range addrMap {
+			messages[int(id)] = make([]runner.Record, 0)
 		}
 		m, err := os.Create("Messages.txt")
 		if err != nil {
@@ -2,15 +2,11 @@ package paxos
 
 import (
 	"bytes"
-	"context"
 	"encoding/json"
-	"net"
 	"os"
 	"testing"
 
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
-	"google.golang.org/grpc/test/bufconn"
 
 	"gomc"
 	"gomc/checking"
@@ -23,7 +19,6 @@ type State struct {
 }
 
 var (
-	bufSize = 1024
 	addrMap = map[int64]string{
 		1: ":50000",
 		2: ":50001",
@@ -65,11 +60,6 @@ var predicates = []checking.Predicate[State]{
 }
 
 func TestPaxosSim(t *testing.T) {
-	addrToIdMap := map[string]int{}
-	for id, addr := range addrMap {
-		addrToIdMap[addr] = int(id)
-	}
-
 	sim := gomc.PrepareSimulation(
 		gomc.WithTreeStateManager(
 			func(t *Server) State {
@@ -91,33 +81,28 @@ func TestPaxosSim(t *testing.T) {
 	defer w.Close()
 	resp := sim.Run(
 		gomc.InitNodeFunc(func(sp eventManager.SimulationParameters) map[int]*Server {
-			lisMap := map[string]*bufconn.Listener{}
-			for _, addr := range addrMap {
-				lisMap[addr] = bufconn.Listen(bufSize)
-			}
-			gem := eventManager.NewGrpcEventManager(addrToIdMap, sp)
+			gem := eventManager.NewGrpcEventManager(sp)
 
 			nodes := make(map[int]*Server)
-			for id, addr := range addrMap {
+			for id := range addrMap {
 				srv, err := NewServer(id, addrMap, gem.WaitForSend(int(id)))
 				if err != nil {
 					t.Errorf("Error while starting simulation: %v", err)
 				}
-				go srv.StartServer(lisMap[addr])
+				go srv.StartServer(gem.Listen(int(id)))
 				sp.CrashSubscribe(int(id), srv.NodeCrash)
 				nodes[int(id)] = srv
 			}
 
 			for id, node := range nodes {
 				node.DialNodes(
-					grpc.WithUnaryInterceptor(gem.UnaryClientControllerInterceptor(id)),
-					grpc.WithContextDialer(
-						func(ctx context.Context, s string) (net.Conn, error) {
-							return lisMap[s].DialContext(ctx)
-						},
-					),
-					grpc.WithBlock(),
-					grpc.WithTransportCredentials(insecure.NewCredentials()),
+					func(to int64, _ string) (*grpc.ClientConn, error) {
+						return gem.Dial(
+							int(to),
+							grpc.WithUnaryInterceptor(gem.UnaryClientControllerInterceptor(id)),
+							grpc.WithBlock(),
+						)
+					},
 				)
 			}
 			return nodes
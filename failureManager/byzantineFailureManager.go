@@ -0,0 +1,287 @@
+package failureManager
+
+import (
+	"fmt"
+	"gomc/event"
+	"gomc/eventManager"
+)
+
+// A mutation of a message sent by a Byzantine node.
+//
+// from and to is the sender and the receiver of the message, msgType is the message handler that is called and params is the parameters of the message.
+// Returns the parameters of the mutated message and true, or false if the mutation does not apply to the message.
+// Mutations can depend on the receiver of the message, which allows Byzantine nodes to equivocate by sending different messages to different nodes.
+// The returned parameters should not share memory with params.
+type Mutation func(from, to int, msgType string, params []any) ([]any, bool)
+
+// The ByzantineFailureManager is a failure manager where some nodes are Byzantine, i.e. they can send arbitrary messages.
+//
+// Messages sent by Byzantine nodes using the Sender are intercepted, and the scheduler can choose between the message and the following alternatives:
+// The message with the parameters altered by one of the user provided mutations.
+// The message being omitted.
+// A message previously sent by the Byzantine node in the run being replayed to the receiver instead of the message.
+//
+// Only one of the alternatives of a message is delivered, and the other alternatives are removed from the scheduler once one of them is chosen.
+// The number of faulty messages is bounded by maxFaults in each run, to keep the state space finite.
+// Messages sent by Byzantine nodes are not affected by the NetworkFaults, since the omitted alternative already represents the message being lost.
+// Byzantine nodes are reported as not correct, so that predicates can exclude them.
+type ByzantineFailureManager[T any] struct {
+	byzantineNodes []int
+	mutations      []Mutation
+	maxFaults      int
+}
+
+// Create a new ByzantineFailureManager
+//
+// byzantineNodes is a slice of node ids of the nodes that are Byzantine.
+// mutations is the mutations that can be applied to messages sent by the Byzantine nodes.
+// maxFaults is the maximum number of faulty messages sent by the Byzantine nodes in a run.
+func NewByzantineFailureManager[T any](byzantineNodes []int, mutations []Mutation, maxFaults int) *ByzantineFailureManager[T] {
+	return &ByzantineFailureManager[T]{
+		byzantineNodes: byzantineNodes,
+		mutations:      mutations,
+		maxFaults:      maxFaults,
+	}
+}
+
+// Create a RunFailureManager that can be used when simulating a run
+// ea is the EventAdder that is used in this run.
+// The EventAdder for the run is provided in the SimulationParameters
+func (bfm ByzantineFailureManager[T]) GetRunFailureManager(ea eventManager.EventAdder) RunFailureManager[T] {
+	return newRunByzantineFailureManager[T](bfm.byzantineNodes, bfm.mutations, bfm.maxFaults)
+}
+
+// The run specific implementation of the ByzantineFailureManager
+//
+// Manages the functionality of the ByzantineFailureManager during the simulation of a run.
+type runByzantineFailureManager[T any] struct {
+	byzantine map[int]bool
+	mutations []Mutation
+	maxFaults int
+
+	correct         map[int]bool
+	failureCallback map[int]func(int, bool)
+
+	// The number of faulty messages in the current run
+	faults int
+	// The messages sent by each Byzantine node in the current run
+	sent map[int][]event.MessageHandlerEvent
+	// The messages that have pending alternatives
+	pending map[*byzantineStatus]bool
+}
+
+// Create a new runByzantineFailureManager
+func newRunByzantineFailureManager[T any](byzantineNodes []int, mutations []Mutation, maxFaults int) *runByzantineFailureManager[T] {
+	byzantine := make(map[int]bool)
+	for _, id := range byzantineNodes {
+		byzantine[id] = true
+	}
+	return &runByzantineFailureManager[T]{
+		byzantine: byzantine,
+		mutations: mutations,
+		maxFaults: maxFaults,
+
+		correct:         make(map[int]bool),
+		failureCallback: make(map[int]func(int, bool)),
+		sent:            make(map[int][]event.MessageHandlerEvent),
+		pending:         make(map[*byzantineStatus]bool),
+	}
+}
+
+// Initialize the FailureManager with the nodes that are used in this run
+//
+// Resets the faulty messages and the messages sent by the Byzantine nodes, since the failure manager is reused across runs.
+func (fm *runByzantineFailureManager[T]) Init(nodes map[int]*T) {
+	for id := range nodes {
+		fm.correct[id] = !fm.byzantine[id]
+	}
+	fm.faults = 0
+	fm.sent = make(map[int][]event.MessageHandlerEvent)
+	fm.pending = make(map[*byzantineStatus]bool)
+}
+
+// Return a map of the node ids and the status of the corresponding node
+//
+// If the status is true the node is correct.
+// if it is false the node is Byzantine.
+func (fm *runByzantineFailureManager[T]) CorrectNodes() map[int]bool {
+	return fm.correct
+}
+
+// Subscribe to updates about node status.
+//
+// id is the id of the node that subscribes to the callback.
+// The callback is a function that is called with the new status of the node when the status.
+// The status of the nodes does not change, so the callback is never called.
+func (fm *runByzantineFailureManager[T]) Subscribe(id int, callback func(int, bool)) {
+	fm.failureCallback[id] = callback
+}
+
+// Add the message together with the faulty alternatives of the message if it is sent by a Byzantine node.
+//
+// Returns false if the message is sent by a correct node.
+// No alternatives are added if the maximum number of faulty messages has been reached.
+func (fm *runByzantineFailureManager[T]) InterceptMessage(ea eventManager.EventAdder, msg event.MessageHandlerEvent) bool {
+	if !fm.byzantine[msg.From()] {
+		return false
+	}
+	previous := fm.sent[msg.From()]
+	fm.sent[msg.From()] = append(previous, msg)
+
+	if fm.faults >= fm.maxFaults {
+		ea.AddEvent(msg)
+		return true
+	}
+
+	status := &byzantineStatus{ea: ea, alternatives: make(map[event.EventId]bool)}
+	fm.pending[status] = true
+	ea.AddEvent(fm.newByzantineMessage("", msg, status, false))
+
+	params := msg.Params()
+	for _, mutate := range fm.mutations {
+		mutated, ok := mutate(msg.From(), msg.To(), msg.Type(), params)
+		if !ok {
+			continue
+		}
+		ea.AddEvent(fm.newByzantineMessage("Mutated", event.NewMessageHandlerEvent(msg.From(), msg.To(), msg.Type(), mutated...), status, true))
+	}
+
+	ea.AddEvent(fm.newByzantineMessage("Omitted", msg, status, true))
+
+	// Replay each distinct message previously sent by the node to the receiver
+	replayed := make(map[event.EventId]bool)
+	for _, old := range previous {
+		replay := event.NewMessageHandlerEvent(old.From(), msg.To(), old.Type(), old.Params()...)
+		if replay.Id() == msg.Id() || replayed[replay.Id()] {
+			continue
+		}
+		replayed[replay.Id()] = true
+		ea.AddEvent(fm.newByzantineMessage("Replayed", replay, status, true))
+	}
+	return true
+}
+
+// The status of a message sent by a Byzantine node
+type byzantineStatus struct {
+	// True if one of the alternatives of the message has been delivered or omitted
+	done bool
+
+	// The EventAdder the alternatives were added to
+	ea eventManager.EventAdder
+	// The ids of the pending alternatives of the message, and whether they are faulty
+	alternatives map[event.EventId]bool
+}
+
+// Create one of the alternatives of a message sent by a Byzantine node
+func (fm *runByzantineFailureManager[T]) newByzantineMessage(kind string, msg event.MessageHandlerEvent, status *byzantineStatus, faulty bool) byzantineMessage {
+	id := msg.Id()
+	if kind != "" {
+		id = event.EventId(fmt.Sprint(kind, " ", msg.Id()))
+	}
+	status.alternatives[id] = faulty
+	return byzantineMessage{
+		msg:     msg,
+		kind:    kind,
+		faulty:  faulty,
+		deliver: kind != "Omitted",
+		status:  status,
+		take:    fm.take,
+		id:      id,
+	}
+}
+
+// Decide that the alternative with the provided id is the one that happens for the message.
+//
+// Returns false if another alternative of the message has happened, or if the alternative is faulty and the maximum number of faulty messages has been reached.
+// Otherwise the other alternatives of the message are removed, and if the maximum number of faulty messages is reached, the faulty alternatives of all messages are removed.
+func (fm *runByzantineFailureManager[T]) take(status *byzantineStatus, faulty bool, id event.EventId) bool {
+	// The alternative is being executed, and is no longer pending
+	delete(status.alternatives, id)
+	if status.done || (faulty && fm.faults >= fm.maxFaults) {
+		return false
+	}
+	status.done = true
+	if faulty {
+		fm.faults++
+	}
+
+	fm.removeAlternatives(status, false)
+	if fm.faults >= fm.maxFaults {
+		for s := range fm.pending {
+			fm.removeAlternatives(s, true)
+		}
+	}
+	return true
+}
+
+// Remove the pending alternatives of the message. If faultyOnly is true, only the faulty alternatives are removed.
+//
+// If the EventAdder does not support removing events, the alternatives have no effect when they are executed.
+func (fm *runByzantineFailureManager[T]) removeAlternatives(status *byzantineStatus, faultyOnly bool) {
+	r, canRemove := status.ea.(eventManager.EventRemover)
+	for id, faulty := range status.alternatives {
+		if faultyOnly && !faulty {
+			continue
+		}
+		delete(status.alternatives, id)
+		if canRemove {
+			r.RemoveEvent(id)
+		}
+	}
+	if len(status.alternatives) == 0 {
+		delete(fm.pending, status)
+	}
+}
+
+// One of the alternatives of a message sent by a Byzantine node.
+//
+// Only the first alternative of the message that is executed takes effect. The other alternatives have no effect.
+type byzantineMessage struct {
+	msg     event.MessageHandlerEvent
+	kind    string
+	faulty  bool
+	deliver bool
+	status  *byzantineStatus
+	take    func(*byzantineStatus, bool, event.EventId) bool
+
+	id event.EventId
+}
+
+// An id that identifies the event.
+// Two events that provided the same input state results in the same output state should have the same id
+//
+// The unaltered message has the same id as the message.
+func (bm byzantineMessage) Id() event.EventId {
+	return bm.id
+}
+
+// Deliver the message if it is the first alternative of the message that is executed.
+func (bm byzantineMessage) Execute(node any, errorChan chan error) {
+	if !bm.take(bm.status, bm.faulty, bm.id) || !bm.deliver {
+		errorChan <- nil
+		return
+	}
+	bm.msg.Execute(node, errorChan)
+}
+
+// The id of the target node, i.e. the node whose state will be changed by the event executing.
+func (bm byzantineMessage) Target() int {
+	return bm.msg.Target()
+}
+
+// The id of the Node that the message is sent to
+func (bm byzantineMessage) To() int {
+	return bm.msg.To()
+}
+
+// The id of the Node that the message is sent from
+func (bm byzantineMessage) From() int {
+	return bm.msg.From()
+}
+
+func (bm byzantineMessage) String() string {
+	if bm.kind == "" {
+		return bm.msg.String()
+	}
+	return fmt.Sprintf("{%v %v}", bm.kind, bm.msg)
+}
@@ -0,0 +1,123 @@
+package failureManager
+
+import (
+	"gomc/event"
+	"strings"
+	"testing"
+)
+
+// Execute the added event whose id starts with the prefix on the node and remove it from the added events
+func executeOn(t *testing.T, sch *MockRunScheduler, prefix string, node *MockNode) {
+	for i, evt := range sch.addedEvents {
+		if strings.HasPrefix(string(evt.Id()), prefix) {
+			sch.addedEvents = append(sch.addedEvents[:i], sch.addedEvents[i+1:]...)
+			errChan := make(chan error, 1)
+			evt.Execute(node, errChan)
+			if err := <-errChan; err != nil {
+				t.Fatalf("Unexpected error executing %v: %v", evt, err)
+			}
+			return
+		}
+	}
+	t.Fatalf("Expected an event with prefix %v to be added. Got: %v", prefix, sch.addedEvents)
+}
+
+// Replaces the value of the message with the id of the receiver
+func equivocate(from, to int, msgType string, params []any) ([]any, bool) {
+	if msgType != "UpdateVal" {
+		return nil, false
+	}
+	return []any{to}, true
+}
+
+func TestByzantineCorrectNodes(t *testing.T) {
+	sch := NewMockRunScheduler()
+	fm := newRunByzantineFailureManager[MockNode]([]int{0}, nil, 1)
+	fm.Init(map[int]*MockNode{0: {}, 1: {}})
+	if fm.CorrectNodes()[0] || !fm.CorrectNodes()[1] {
+		t.Errorf("Expected the Byzantine node to not be correct. Got: %v", fm.CorrectNodes())
+	}
+
+	// Messages sent by correct nodes are not intercepted
+	if fm.InterceptMessage(sch, event.NewMessageHandlerEvent(1, 0, "UpdateVal", 1)) {
+		t.Errorf("Did not expect a message from a correct node to be intercepted")
+	}
+}
+
+func TestByzantineMutation(t *testing.T) {
+	sch := NewMockRunScheduler()
+	fm := newRunByzantineFailureManager[MockNode]([]int{0}, []Mutation{equivocate}, 1)
+	fm.Init(map[int]*MockNode{0: {}, 1: {}, 2: {}})
+
+	if !fm.InterceptMessage(sch, event.NewMessageHandlerEvent(0, 1, "UpdateVal", 5)) {
+		t.Fatalf("Expected the message from the Byzantine node to be intercepted")
+	}
+	// The message, the mutated message and the omission
+	if len(sch.addedEvents) != 3 {
+		t.Fatalf("Expected 3 alternatives to be added. Got: %v", sch.addedEvents)
+	}
+
+	node := &MockNode{}
+	executeOn(t, sch, "Mutated", node)
+	if node.val != 1 {
+		t.Errorf("Expected the mutated message to be delivered. Got: %v", node.val)
+	}
+	// The other alternatives are removed
+	if len(sch.addedEvents) != 0 {
+		t.Errorf("Expected the other alternatives to be removed. Got: %v", sch.addedEvents)
+	}
+
+	// The maximum number of faults has been reached. Only the message is added
+	fm.InterceptMessage(sch, event.NewMessageHandlerEvent(0, 2, "UpdateVal", 5))
+	if len(sch.addedEvents) != 1 {
+		t.Errorf("Expected only the message to be added. Got: %v", sch.addedEvents)
+	}
+}
+
+func TestByzantineReplay(t *testing.T) {
+	sch := NewMockRunScheduler()
+	fm := newRunByzantineFailureManager[MockNode]([]int{0}, nil, 2)
+	fm.Init(map[int]*MockNode{0: {}, 1: {}})
+
+	fm.InterceptMessage(sch, event.NewMessageHandlerEvent(0, 1, "UpdateVal", 5))
+	node := &MockNode{}
+	executeOn(t, sch, "Message", node)
+	sch.addedEvents = nil
+
+	fm.InterceptMessage(sch, event.NewMessageHandlerEvent(0, 1, "UpdateVal", 6))
+	executeOn(t, sch, "Replayed", node)
+	if node.val != 5 {
+		t.Errorf("Expected the old message to be replayed. Got: %v", node.val)
+	}
+	for _, evt := range sch.addedEvents {
+		if strings.HasPrefix(string(evt.Id()), "Message") {
+			t.Errorf("Expected the message to be removed after it was replaced. Got: %v", sch.addedEvents)
+		}
+	}
+
+	// The messages sent by the node are reset between runs
+	fm.Init(map[int]*MockNode{0: {}, 1: {}})
+	sch.addedEvents = nil
+	fm.InterceptMessage(sch, event.NewMessageHandlerEvent(0, 1, "UpdateVal", 6))
+	for _, evt := range sch.addedEvents {
+		if strings.HasPrefix(string(evt.Id()), "Replayed") {
+			t.Errorf("Did not expect messages from the previous run to be replayed. Got: %v", sch.addedEvents)
+		}
+	}
+}
+
+func TestByzantineBudgetRemovesFaults(t *testing.T) {
+	sch := NewMockRunScheduler()
+	fm := newRunByzantineFailureManager[MockNode]([]int{0}, nil, 1)
+	fm.Init(map[int]*MockNode{0: {}, 1: {}, 2: {}})
+
+	fm.InterceptMessage(sch, event.NewMessageHandlerEvent(0, 1, "UpdateVal", 5))
+	fm.InterceptMessage(sch, event.NewMessageHandlerEvent(0, 2, "UpdateVal", 5))
+	node := &MockNode{}
+	executeOn(t, sch, "Omitted Message 0 1", node)
+
+	// The budget is used, so only the message to node 2 is pending
+	if len(sch.addedEvents) != 1 || !strings.HasPrefix(string(sch.addedEvents[0].Id()), "Message 0 2") {
+		t.Errorf("Expected the faulty alternatives to be removed. Got: %v", sch.addedEvents)
+	}
+}
@@ -0,0 +1,204 @@
+package failureManager
+
+import (
+	"errors"
+	"gomc/event"
+	"gomc/eventManager"
+)
+
+// The CrashRecoveryFailureManager is a failure manager that implements crash-recovery failures.
+//
+// It is configured with a slice of nodes that will crash at some point during the simulation.
+// A crashed node will recover at some point after it has crashed, and may crash again until it has crashed maxCrashes times.
+//
+// The state of a node is divided into stable storage, which survives crashes, and volatile state, which is lost when the node crashes.
+// The stable storage of the node is collected when the node crashes, and is used to rebuild the node when it recovers.
+// Events that are pending for the node when it recovers will be executed on the rebuilt node.
+type CrashRecoveryFailureManager[T, P any] struct {
+	crashFunc    func(*T)
+	persist      func(*T) P
+	recoverFunc  func(id int, persisted P, sp eventManager.SimulationParameters) *T
+	maxCrashes   int
+	failingNodes []int
+}
+
+// Create a new CrashRecoveryFailureManager
+//
+// crashFunc is a function performing the crash on the node.
+// It should close all network connections and stop all ongoing executions on the node.
+// Events executed on the node after the crash should have no effect.
+// persist is a function collecting the stable storage of the node. It is called when the node crashes.
+// The returned value should not share memory with the node.
+// recoverFunc is a function that rebuilds the node with the provided id from its stable storage.
+// It is provided the SimulationParameters of the run, which should be used to configure new Event Managers for the new node.
+// The crash subscriptions of the crashed node are removed, and CrashSubscribe only subscribes callbacks of the new node.
+// maxCrashes is the maximum number of times each failing node will crash during a run.
+// failingNodes is a slice of node ids of the nodes that will crash at some point during a run.
+func NewCrashRecoveryFailureManager[T, P any](crashFunc func(*T), persist func(*T) P, recoverFunc func(id int, persisted P, sp eventManager.SimulationParameters) *T, maxCrashes int, failingNodes []int) *CrashRecoveryFailureManager[T, P] {
+	return &CrashRecoveryFailureManager[T, P]{
+		crashFunc:    crashFunc,
+		persist:      persist,
+		recoverFunc:  recoverFunc,
+		maxCrashes:   maxCrashes,
+		failingNodes: failingNodes,
+	}
+}
+
+// Create a RunFailureManager that can be used when simulating a run
+// ea is the EventAdder that is used in this run.
+// The EventAdder for the run is provided in the SimulationParameters
+func (crfm CrashRecoveryFailureManager[T, P]) GetRunFailureManager(ea eventManager.EventAdder) RunFailureManager[T] {
+	return newRunCrashRecoveryFailureManager(ea, crfm.crashFunc, crfm.persist, crfm.recoverFunc, crfm.maxCrashes, crfm.failingNodes)
+}
+
+// The run specific implementation of the CrashRecoveryFailureManager
+//
+// Manages the functionality of the CrashRecoveryFailureManager during the simulation of a run.
+type runCrashRecoveryFailureManager[T, P any] struct {
+	ea           eventManager.EventAdder
+	sp           eventManager.SimulationParameters
+	crashFunc    func(*T)
+	persist      func(*T) P
+	recoverFunc  func(id int, persisted P, sp eventManager.SimulationParameters) *T
+	maxCrashes   int
+	failingNodes []int
+
+	correct         map[int]bool
+	nodes           map[int]*T
+	persisted       map[int]P
+	crashes         map[int]int
+	failureCallback map[int]func(int, bool)
+}
+
+// Create a new runCrashRecoveryFailureManager
+func newRunCrashRecoveryFailureManager[T, P any](ea eventManager.EventAdder, crashFunc func(*T), persist func(*T) P, recoverFunc func(int, P, eventManager.SimulationParameters) *T, maxCrashes int, failingNodes []int) *runCrashRecoveryFailureManager[T, P] {
+	return &runCrashRecoveryFailureManager[T, P]{
+		ea:           ea,
+		crashFunc:    crashFunc,
+		persist:      persist,
+		recoverFunc:  recoverFunc,
+		maxCrashes:   maxCrashes,
+		failingNodes: failingNodes,
+
+		correct:         make(map[int]bool),
+		persisted:       make(map[int]P),
+		crashes:         make(map[int]int),
+		failureCallback: make(map[int]func(int, bool)),
+	}
+}
+
+// Receive the SimulationParameters used to create the nodes of the run.
+//
+// The SimulationParameters are provided to recoverFunc when rebuilding nodes.
+func (fm *runCrashRecoveryFailureManager[T, P]) SetSimulationParameters(sp eventManager.SimulationParameters) {
+	fm.sp = sp
+}
+
+// Initialize the FailureManager with the nodes that are used in this run
+//
+// Resets the number of crashes and the stable storage of the nodes, since the failure manager is reused across runs.
+func (fm *runCrashRecoveryFailureManager[T, P]) Init(nodes map[int]*T) {
+	for id := range nodes {
+		fm.correct[id] = true
+	}
+	fm.crashes = make(map[int]int)
+	fm.persisted = make(map[int]P)
+
+	fm.nodes = nodes
+
+	// Schedule the first crash of the failing nodes
+	if fm.maxCrashes < 1 {
+		return
+	}
+	for _, id := range fm.failingNodes {
+		if _, ok := nodes[id]; !ok {
+			continue
+		}
+		fm.ea.AddEvent(
+			event.NewCrashEvent(id, fm.nodeCrash),
+		)
+	}
+}
+
+// Return a map of the node ids and the status of the corresponding node
+//
+// If the status is true the node is currently running.
+// if it is false the node has crashed.
+func (fm *runCrashRecoveryFailureManager[T, P]) CorrectNodes() map[int]bool {
+	return fm.correct
+}
+
+// Perform the crash of the node with the provided id.
+//
+// Collects the stable storage of the node and schedules the recovery of the node.
+// The method is called by the CrashEvent when it is executed.
+func (fm *runCrashRecoveryFailureManager[T, P]) nodeCrash(nodeId int) error {
+	node, ok := fm.nodes[nodeId]
+	if !ok {
+		return errors.New("FailureManager: Received NodeCrash for node that is not added to the system")
+	}
+
+	if status := fm.correct[nodeId]; !status {
+		return errors.New("FailureManager: Received NodeCrash for node that has already crashed")
+	}
+	fm.correct[nodeId] = false
+	fm.crashes[nodeId]++
+
+	fm.crashFunc(node)
+	fm.persisted[nodeId] = fm.persist(node)
+
+	for id, f := range fm.failureCallback {
+		fm.ea.AddEvent(event.NewCrashDetection(
+			id,
+			nodeId,
+			f,
+		))
+	}
+
+	fm.ea.AddEvent(event.NewRecoverEvent(nodeId, fm.nodeRecover))
+	return nil
+}
+
+// Perform the recovery of the node with the provided id.
+//
+// Rebuilds the node from its stable storage and replaces the crashed node.
+// The callbacks subscribed by the crashed node are removed, and the new node is given SimulationParameters where the crash subscriptions are scoped to the new node.
+// Schedules a new crash if the node has crashed less than maxCrashes times.
+// The method is called by the RecoverEvent when it is executed.
+func (fm *runCrashRecoveryFailureManager[T, P]) nodeRecover(nodeId int) error {
+	if _, ok := fm.nodes[nodeId]; !ok {
+		return errors.New("FailureManager: Received NodeRecover for node that is not added to the system")
+	}
+
+	if status := fm.correct[nodeId]; status {
+		return errors.New("FailureManager: Received NodeRecover for node that has not crashed")
+	}
+
+	delete(fm.failureCallback, nodeId)
+	sp := fm.sp
+	sp.CrashSubscribe = eventManager.CombineCrashSubscriptions(fm.Subscribe)
+	fm.nodes[nodeId] = fm.recoverFunc(nodeId, fm.persisted[nodeId], sp)
+	fm.correct[nodeId] = true
+
+	for id, f := range fm.failureCallback {
+		fm.ea.AddEvent(event.NewRecoveryDetection(
+			id,
+			nodeId,
+			f,
+		))
+	}
+
+	if fm.crashes[nodeId] < fm.maxCrashes {
+		fm.ea.AddEvent(event.NewCrashEvent(nodeId, fm.nodeCrash))
+	}
+	return nil
+}
+
+// Subscribe to updates about node status.
+//
+// id is the id of the node that subscribes to the callback.
+// The callback is a function that is called with the new status of the node when the status.
+// The callbacks subscribed for a node are removed when the node recovers, so a recovered node should subscribe again when it is rebuilt.
+func (fm *runCrashRecoveryFailureManager[T, P]) Subscribe(id int, callback func(int, bool)) {
+	fm.failureCallback[id] = callback
+}
@@ -0,0 +1,121 @@
+package failureManager
+
+import (
+	"gomc/event"
+	"gomc/eventManager"
+	"testing"
+)
+
+func newTestCrashRecoveryFailureManager(sch *MockRunScheduler, maxCrashes int, failingNodes ...int) *runCrashRecoveryFailureManager[MockNode, int] {
+	return newRunCrashRecoveryFailureManager(
+		sch,
+		func(t *MockNode) { t.crashed = true },
+		func(t *MockNode) int { return t.val },
+		func(id int, persisted int, _ eventManager.SimulationParameters) *MockNode {
+			return &MockNode{Id: id, val: persisted}
+		},
+		maxCrashes,
+		failingNodes,
+	)
+}
+
+func TestCrashRecovery(t *testing.T) {
+	sch := NewMockRunScheduler()
+	fm := newTestCrashRecoveryFailureManager(sch, 2, 0)
+	nodes := map[int]*MockNode{0: {Id: 0, val: 5}, 1: {Id: 1}}
+	fm.Init(nodes)
+	fm.Subscribe(1, func(int, bool) {})
+
+	if len(sch.addedEvents) != 1 {
+		t.Fatalf("Expected one crash to be scheduled. Got: %v", sch.addedEvents)
+	}
+
+	// First crash
+	crashed := nodes[0]
+	if err := fm.nodeCrash(0); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !crashed.crashed || fm.CorrectNodes()[0] {
+		t.Errorf("Expected node 0 to be crashed")
+	}
+	if _, ok := sch.addedEvents[len(sch.addedEvents)-1].(event.RecoverEvent); !ok {
+		t.Errorf("Expected a RecoverEvent to be scheduled. Got: %v", sch.addedEvents)
+	}
+	if err := fm.nodeCrash(0); err == nil {
+		t.Errorf("Expected an error when crashing a crashed node")
+	}
+
+	// Recovery rebuilds the node from stable storage
+	sch.addedEvents = nil
+	if err := fm.nodeRecover(0); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if nodes[0] == crashed || nodes[0].crashed || nodes[0].val != 5 {
+		t.Errorf("Expected node 0 to be rebuilt from stable storage. Got: %+v", nodes[0])
+	}
+	if !fm.CorrectNodes()[0] {
+		t.Errorf("Expected node 0 to be correct after recovering")
+	}
+	if len(sch.addedEvents) != 2 {
+		t.Fatalf("Expected a recovery detection and a new crash to be scheduled. Got: %v", sch.addedEvents)
+	}
+	if _, ok := sch.addedEvents[1].(event.CrashEvent); !ok {
+		t.Errorf("Expected a new crash to be scheduled. Got: %v", sch.addedEvents)
+	}
+	if err := fm.nodeRecover(0); err == nil {
+		t.Errorf("Expected an error when recovering a correct node")
+	}
+
+	// Second crash. The node has reached the maximum number of crashes and no further crash is scheduled
+	fm.nodeCrash(0)
+	sch.addedEvents = nil
+	fm.nodeRecover(0)
+	for _, evt := range sch.addedEvents {
+		if _, ok := evt.(event.CrashEvent); ok {
+			t.Errorf("Did not expect a crash to be scheduled after reaching the maximum number of crashes")
+		}
+	}
+}
+
+func TestCrashRecoverySubscriptions(t *testing.T) {
+	sch := NewMockRunScheduler()
+	// The instances of node 0 whose callbacks have been called
+	called := []int{}
+	instance := 0
+	subscribe := func(sp eventManager.SimulationParameters, inst int) {
+		// Subscribe twice, as a node and an Event Manager would
+		sp.CrashSubscribe(0, func(int, bool) { called = append(called, inst) })
+		sp.CrashSubscribe(0, func(int, bool) { called = append(called, inst) })
+	}
+	fm := newRunCrashRecoveryFailureManager(
+		sch,
+		func(t *MockNode) { t.crashed = true },
+		func(t *MockNode) int { return t.val },
+		func(id int, persisted int, sp eventManager.SimulationParameters) *MockNode {
+			instance++
+			subscribe(sp, instance)
+			return &MockNode{Id: id, val: persisted}
+		},
+		2,
+		[]int{0},
+	)
+	sp := eventManager.SimulationParameters{EventAdder: sch, CrashSubscribe: eventManager.CombineCrashSubscriptions(fm.Subscribe)}
+	fm.SetSimulationParameters(sp)
+	subscribe(sp, instance)
+	fm.Init(map[int]*MockNode{0: {Id: 0}, 1: {Id: 1}})
+
+	fm.nodeCrash(0)
+	fm.nodeRecover(0)
+
+	// Only the callbacks of the recovered instance detect the second crash
+	sch.addedEvents = nil
+	fm.nodeCrash(0)
+	for _, evt := range sch.addedEvents {
+		if cd, ok := evt.(event.CrashDetection); ok {
+			cd.Execute(nil, make(chan error, 1))
+		}
+	}
+	if len(called) != 2 || called[0] != 1 || called[1] != 1 {
+		t.Errorf("Expected only the two callbacks of the recovered instance to be called. Got callbacks of instances: %v", called)
+	}
+}
@@ -0,0 +1,232 @@
+package failureManager
+
+import (
+	"errors"
+	"gomc/event"
+	"gomc/eventManager"
+
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+)
+
+// The EventuallyPerfectFailureManager is a failure manager that implements the EventuallyPerfectFailureDetector abstraction in a crash-stop system.
+//
+// Crashed nodes are eventually detected by all subscribed nodes, as with the PerfectFailureManager.
+// In addition, a subscribed node can falsely suspect a correct node, and will later restore it.
+// The number of false suspicions is bounded by maxFalseSuspicions for each run, to keep the state space finite.
+type EventuallyPerfectFailureManager[T any] struct {
+	crashFunc          func(*T)
+	failingNodes       []int
+	maxFalseSuspicions int
+}
+
+// Create a new EventuallyPerfectFailureManager
+//
+// Implements the EventuallyPerfectFailureDetector abstraction in a crash-stop system.
+// crashFunc is a function performing the crash on the node.
+// It should close all network connections and stop all ongoing executions on the node.
+// Events executed on the node after the crash should have no effect.
+// maxFalseSuspicions is the maximum number of false suspicions in a run.
+// failingNodes is a slice of node ids of the nodes that will crash at some point during a run.
+func NewEventuallyPerfectFailureManager[T any](crashFunc func(*T), maxFalseSuspicions int, failingNodes []int) *EventuallyPerfectFailureManager[T] {
+	return &EventuallyPerfectFailureManager[T]{
+		crashFunc:          crashFunc,
+		failingNodes:       failingNodes,
+		maxFalseSuspicions: maxFalseSuspicions,
+	}
+}
+
+// Create a RunFailureManager that can be used when simulating a run
+// ea is the EventAdder that is used in this run.
+// The EventAdder for the run is provided in the SimulationParameters
+func (epfm EventuallyPerfectFailureManager[T]) GetRunFailureManager(ea eventManager.EventAdder) RunFailureManager[T] {
+	return newRunEventuallyPerfectFailureManager(ea, epfm.crashFunc, epfm.maxFalseSuspicions, epfm.failingNodes)
+}
+
+// A pair of nodes where the observer suspects the suspected node
+type suspicion struct {
+	observer  int
+	suspected int
+}
+
+// The run specific implementation of the EventuallyPerfectFailureManager
+//
+// Manages the functionality of the EventuallyPerfectFailureManager during the simulation of a run.
+type runEventuallyPerfectFailureManager[T any] struct {
+	ea                 eventManager.EventAdder
+	crashFunc          func(*T)
+	failingNodes       []int
+	maxFalseSuspicions int
+
+	correct         map[int]bool
+	nodes           map[int]*T
+	failureCallback map[int]func(int, bool)
+
+	// The number of false suspicions in the current run
+	falseSuspicions int
+	// The false suspicions that have not been restored
+	suspected map[suspicion]bool
+	// The false suspicions that are scheduled but not executed
+	pending map[suspicion]bool
+}
+
+// Create a new runEventuallyPerfectFailureManager
+func newRunEventuallyPerfectFailureManager[T any](ea eventManager.EventAdder, crashFunc func(*T), maxFalseSuspicions int, failingNodes []int) *runEventuallyPerfectFailureManager[T] {
+	return &runEventuallyPerfectFailureManager[T]{
+		ea:                 ea,
+		crashFunc:          crashFunc,
+		failingNodes:       failingNodes,
+		maxFalseSuspicions: maxFalseSuspicions,
+
+		correct:         make(map[int]bool),
+		failureCallback: make(map[int]func(int, bool)),
+		suspected:       make(map[suspicion]bool),
+		pending:         make(map[suspicion]bool),
+	}
+}
+
+// Initialize the FailureManager with the nodes that are used in this run
+//
+// Schedules the crashes of the failing nodes, and a false suspicion of each node by each subscribed node.
+func (fm *runEventuallyPerfectFailureManager[T]) Init(nodes map[int]*T) {
+	for id := range nodes {
+		fm.correct[id] = true
+	}
+	fm.nodes = nodes
+	fm.falseSuspicions = 0
+	fm.suspected = make(map[suspicion]bool)
+	fm.pending = make(map[suspicion]bool)
+
+	for _, id := range fm.failingNodes {
+		if _, ok := nodes[id]; !ok {
+			continue
+		}
+		fm.ea.AddEvent(
+			event.NewCrashEvent(id, fm.nodeCrash),
+		)
+	}
+
+	if fm.maxFalseSuspicions < 1 {
+		return
+	}
+	observers := maps.Keys(fm.failureCallback)
+	slices.Sort(observers)
+	ids := maps.Keys(nodes)
+	slices.Sort(ids)
+	for _, observer := range observers {
+		for _, suspected := range ids {
+			if observer == suspected {
+				continue
+			}
+			fm.addFalseSuspicion(suspicion{observer: observer, suspected: suspected})
+		}
+	}
+}
+
+// Return a map of the node ids and the status of the corresponding node
+//
+// If the status is true the node is currently running.
+// if it is false the node has crashed.
+// False suspicions does not affect the status of the node.
+func (fm *runEventuallyPerfectFailureManager[T]) CorrectNodes() map[int]bool {
+	return fm.correct
+}
+
+// Perform the crash of the node with the provided id.
+//
+// The method is called by the CrashEvent when it is executed.
+func (fm *runEventuallyPerfectFailureManager[T]) nodeCrash(nodeId int) error {
+	node, ok := fm.nodes[nodeId]
+	if !ok {
+		return errors.New("FailureManager: Received NodeCrash for node that is not added to the system")
+	}
+
+	if status := fm.correct[nodeId]; !status {
+		return errors.New("FailureManager: Received NodeCrash for node that has already crashed. Is failStop abstraction so node can not crash again.")
+	}
+	fm.correct[nodeId] = false
+
+	fm.crashFunc(node)
+
+	// A crashed node can no longer be falsely suspected
+	fm.removeFalseSuspicions(func(s suspicion) bool { return s.suspected == nodeId })
+
+	for id, f := range fm.failureCallback {
+		fm.ea.AddEvent(event.NewCrashDetection(
+			id,
+			nodeId,
+			f,
+		))
+	}
+	return nil
+}
+
+// Add an event where the observer falsely suspects the suspected node
+func (fm *runEventuallyPerfectFailureManager[T]) addFalseSuspicion(s suspicion) {
+	fm.pending[s] = true
+	fm.ea.AddEvent(event.NewFalseSuspicion(s.observer, s.suspected, func(id int, status bool) {
+		fm.falselySuspect(s, status)
+	}))
+}
+
+// Perform the false suspicion.
+//
+// The suspicion is ignored if the maximum number of false suspicions has been reached, or if the suspected node has crashed.
+// Otherwise the observer is notified and a restore of the suspected node is scheduled.
+// Once the maximum number of false suspicions is reached, the remaining false suspicions are removed.
+func (fm *runEventuallyPerfectFailureManager[T]) falselySuspect(s suspicion, status bool) {
+	delete(fm.pending, s)
+	if fm.falseSuspicions >= fm.maxFalseSuspicions || !fm.correct[s.suspected] || fm.suspected[s] {
+		return
+	}
+	fm.falseSuspicions++
+	if fm.falseSuspicions >= fm.maxFalseSuspicions {
+		fm.removeFalseSuspicions(func(suspicion) bool { return true })
+	}
+	fm.suspected[s] = true
+	fm.failureCallback[s.observer](s.suspected, status)
+
+	fm.ea.AddEvent(event.NewRestore(s.observer, s.suspected, func(id int, status bool) {
+		fm.restore(s, status)
+	}))
+}
+
+// Restore the falsely suspected node.
+//
+// The restore is ignored if the suspected node has crashed since it was suspected.
+// Otherwise the observer is notified, and a new false suspicion is scheduled if the maximum number of false suspicions has not been reached.
+func (fm *runEventuallyPerfectFailureManager[T]) restore(s suspicion, status bool) {
+	delete(fm.suspected, s)
+	if !fm.correct[s.suspected] {
+		return
+	}
+	fm.failureCallback[s.observer](s.suspected, status)
+
+	if fm.falseSuspicions < fm.maxFalseSuspicions {
+		fm.addFalseSuspicion(s)
+	}
+}
+
+// Remove the pending false suspicions that match the filter.
+//
+// If the EventAdder does not support removing events, the false suspicions have no effect when they are executed.
+func (fm *runEventuallyPerfectFailureManager[T]) removeFalseSuspicions(filter func(suspicion) bool) {
+	for s := range fm.pending {
+		if !filter(s) {
+			continue
+		}
+		delete(fm.pending, s)
+		if r, ok := fm.ea.(eventManager.EventRemover); ok {
+			r.RemoveEvent(event.NewFalseSuspicion(s.observer, s.suspected, nil).Id())
+		}
+	}
+}
+
+// Subscribe to updates about node status.
+//
+// id is the id of the node that subscribes to the callback.
+// The callback is a function that is called with the new status of the node when the status.
+// The status is false when a node is suspected and true when a falsely suspected node is restored.
+func (fm *runEventuallyPerfectFailureManager[T]) Subscribe(id int, callback func(int, bool)) {
+	fm.failureCallback[id] = callback
+}
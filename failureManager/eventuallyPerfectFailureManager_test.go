@@ -0,0 +1,92 @@
+package failureManager
+
+import (
+	"gomc/event"
+	"testing"
+)
+
+type statusUpdate struct {
+	id     int
+	status bool
+}
+
+// Execute the first added event of type E and remove it from the added events
+func executeFirst[E event.Event](t *testing.T, sch *MockRunScheduler) {
+	for i, evt := range sch.addedEvents {
+		if _, ok := evt.(E); ok {
+			sch.addedEvents = append(sch.addedEvents[:i], sch.addedEvents[i+1:]...)
+			errChan := make(chan error, 1)
+			evt.Execute(nil, errChan)
+			if err := <-errChan; err != nil {
+				t.Fatalf("Unexpected error executing %v: %v", evt, err)
+			}
+			return
+		}
+	}
+	t.Fatalf("Expected an event of type %T to be added. Got: %v", *new(E), sch.addedEvents)
+}
+
+func countEvents[E event.Event](sch *MockRunScheduler) int {
+	count := 0
+	for _, evt := range sch.addedEvents {
+		if _, ok := evt.(E); ok {
+			count++
+		}
+	}
+	return count
+}
+
+func TestEventuallyPerfectFalseSuspicion(t *testing.T) {
+	sch := NewMockRunScheduler()
+	fm := newRunEventuallyPerfectFailureManager(sch, func(t *MockNode) { t.crashed = true }, 1, []int{})
+	updates := []statusUpdate{}
+	fm.Subscribe(0, func(id int, status bool) { updates = append(updates, statusUpdate{id, status}) })
+	fm.Init(map[int]*MockNode{0: {}, 1: {}, 2: {}})
+
+	// Node 0 can suspect node 1 and node 2
+	if n := countEvents[event.SuspicionEvent](sch); n != 2 {
+		t.Fatalf("Expected 2 false suspicions to be scheduled. Got: %v", sch.addedEvents)
+	}
+
+	executeFirst[event.SuspicionEvent](t, sch)
+	if len(updates) != 1 || updates[0] != (statusUpdate{1, false}) {
+		t.Errorf("Expected node 1 to be suspected. Got: %v", updates)
+	}
+	if !fm.CorrectNodes()[1] {
+		t.Errorf("Did not expect a false suspicion to change the status of the node")
+	}
+
+	// The maximum number of false suspicions has been reached. The suspicion of node 2 is removed
+	if n := countEvents[event.SuspicionEvent](sch); n != 1 {
+		t.Errorf("Expected only the restore of node 1 to be pending. Got: %v", sch.addedEvents)
+	}
+
+	// Restore node 1
+	executeFirst[event.SuspicionEvent](t, sch)
+	if len(updates) != 2 || updates[1] != (statusUpdate{1, true}) {
+		t.Errorf("Expected node 1 to be restored. Got: %v", updates)
+	}
+	if n := countEvents[event.SuspicionEvent](sch); n != 0 {
+		t.Errorf("Did not expect more false suspicions to be scheduled. Got: %v", sch.addedEvents)
+	}
+}
+
+func TestEventuallyPerfectRestoreCrashedNode(t *testing.T) {
+	sch := NewMockRunScheduler()
+	fm := newRunEventuallyPerfectFailureManager(sch, func(t *MockNode) { t.crashed = true }, 2, []int{1})
+	updates := []statusUpdate{}
+	fm.Subscribe(0, func(id int, status bool) { updates = append(updates, statusUpdate{id, status}) })
+	fm.Init(map[int]*MockNode{0: {}, 1: {}})
+
+	executeFirst[event.SuspicionEvent](t, sch)
+	executeFirst[event.CrashEvent](t, sch)
+
+	// Node 1 has crashed. It should not be restored
+	executeFirst[event.SuspicionEvent](t, sch)
+	if len(updates) != 1 {
+		t.Errorf("Did not expect a crashed node to be restored. Got: %v", updates)
+	}
+	if n := countEvents[event.CrashDetection](sch); n != 1 {
+		t.Errorf("Expected the crash to be detected. Got: %v", sch.addedEvents)
+	}
+}
@@ -34,3 +34,33 @@ type RunFailureManager[T any] interface {
 	// A node should only subscribe to node crashes once.
 	Subscribe(id int, callback func(id int, status bool))
 }
+
+// Implemented by RunFailureManagers that need the SimulationParameters of the run, e.g. to create new nodes during the run.
+type SimulationParametersReceiver interface {
+	// Receive the SimulationParameters used to create the nodes of the run.
+	//
+	// Called before Init.
+	SetSimulationParameters(sp eventManager.SimulationParameters)
+}
+
+// Implemented by RunFailureManagers that provide a leader oracle, i.e. the Omega abstraction.
+//
+// The LeaderSubscribe method is provided to the nodes in the SimulationParameters.
+type LeaderOracle interface {
+	// Subscribe to leader changes.
+	//
+	// id is the id of the node that subscribes to the callback.
+	// The callback is called with the id of the node that is trusted as leader when the trusted leader changes.
+	LeaderSubscribe(id int, callback func(leader int))
+}
+
+// Implemented by RunFailureManagers that partition the network.
+//
+// Message events between nodes in different groups of the active partition are dropped by the simulator.
+type Partitioner interface {
+	// Return a map of the node ids and the group of the corresponding node in the active partition.
+	//
+	// Nodes can only communicate with nodes in the same group.
+	// Returns nil if the network is not partitioned.
+	Partition() map[int]int
+}
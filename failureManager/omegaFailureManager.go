@@ -0,0 +1,253 @@
+package failureManager
+
+import (
+	"errors"
+	"gomc/event"
+	"gomc/eventManager"
+
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+)
+
+// The OmegaFailureManager is a failure manager that provides the Omega leader oracle in a crash-stop system.
+//
+// Crashed nodes are detected by all nodes subscribed to crash updates, as with the PerfectFailureManager.
+// In addition, nodes can subscribe to leader changes using the LeaderSubscribe function in the SimulationParameters.
+//
+// The oracle trusts a single leader at a time, initially the node with the lowest id.
+// Changes of the leader are nondeterministic choices, and are bounded by maxLeaderChanges for each run.
+// When the trusted leader crashes the oracle changes the leader to one of the correct nodes, without counting it against the bound.
+// Each subscribed node is notified of a leader change by a separate event, so that nodes can temporarily disagree about the leader.
+// Notifications of a leader that is no longer trusted are discarded.
+// Pending changes that are no longer valid are removed, if the EventAdder supports removing events.
+// Since the number of changes is bounded, a single correct leader is eventually trusted by all correct nodes.
+type OmegaFailureManager[T any] struct {
+	crashFunc        func(*T)
+	failingNodes     []int
+	maxLeaderChanges int
+}
+
+// Create a new OmegaFailureManager
+//
+// crashFunc is a function performing the crash on the node.
+// It should close all network connections and stop all ongoing executions on the node.
+// Events executed on the node after the crash should have no effect.
+// maxLeaderChanges is the maximum number of leader changes in a run, not counting changes caused by the leader crashing.
+// failingNodes is a slice of node ids of the nodes that will crash at some point during a run.
+func NewOmegaFailureManager[T any](crashFunc func(*T), maxLeaderChanges int, failingNodes []int) *OmegaFailureManager[T] {
+	return &OmegaFailureManager[T]{
+		crashFunc:        crashFunc,
+		failingNodes:     failingNodes,
+		maxLeaderChanges: maxLeaderChanges,
+	}
+}
+
+// Create a RunFailureManager that can be used when simulating a run
+// ea is the EventAdder that is used in this run.
+// The EventAdder for the run is provided in the SimulationParameters
+func (ofm OmegaFailureManager[T]) GetRunFailureManager(ea eventManager.EventAdder) RunFailureManager[T] {
+	return newRunOmegaFailureManager(ea, ofm.crashFunc, ofm.maxLeaderChanges, ofm.failingNodes)
+}
+
+// The run specific implementation of the OmegaFailureManager
+//
+// Manages the functionality of the OmegaFailureManager during the simulation of a run.
+type runOmegaFailureManager[T any] struct {
+	ea               eventManager.EventAdder
+	crashFunc        func(*T)
+	failingNodes     []int
+	maxLeaderChanges int
+
+	correct         map[int]bool
+	nodes           map[int]*T
+	failureCallback map[int]func(int, bool)
+	leaderCallback  map[int]func(int)
+
+	// The currently trusted leader
+	leader int
+	// The number of leader changes in the current run. Used to discard pending changes that are no longer valid
+	epoch int
+	// The number of nondeterministic leader changes in the current run
+	leaderChanges int
+	// The target of the pending leader changes of the current epoch
+	pending map[int]bool
+}
+
+// Create a new runOmegaFailureManager
+func newRunOmegaFailureManager[T any](ea eventManager.EventAdder, crashFunc func(*T), maxLeaderChanges int, failingNodes []int) *runOmegaFailureManager[T] {
+	return &runOmegaFailureManager[T]{
+		ea:               ea,
+		crashFunc:        crashFunc,
+		failingNodes:     failingNodes,
+		maxLeaderChanges: maxLeaderChanges,
+
+		correct:         make(map[int]bool),
+		failureCallback: make(map[int]func(int, bool)),
+		leaderCallback:  make(map[int]func(int)),
+		pending:         make(map[int]bool),
+	}
+}
+
+// Initialize the FailureManager with the nodes that are used in this run
+//
+// Trusts the node with the lowest id as leader and notifies the subscribed nodes.
+// Schedules the crashes of the failing nodes and the possible leader changes.
+func (fm *runOmegaFailureManager[T]) Init(nodes map[int]*T) {
+	for id := range nodes {
+		fm.correct[id] = true
+	}
+	fm.nodes = nodes
+	fm.epoch = 0
+	fm.leaderChanges = 0
+	fm.pending = make(map[int]bool)
+
+	ids := maps.Keys(nodes)
+	slices.Sort(ids)
+	if len(ids) > 0 {
+		fm.leader = ids[0]
+	}
+
+	for _, id := range fm.failingNodes {
+		if _, ok := nodes[id]; !ok {
+			continue
+		}
+		fm.ea.AddEvent(
+			event.NewCrashEvent(id, fm.nodeCrash),
+		)
+	}
+
+	fm.notifyLeader()
+	if fm.maxLeaderChanges > 0 {
+		fm.scheduleLeaderChanges()
+	}
+}
+
+// Return a map of the node ids and the status of the corresponding node
+//
+// If the status is true the node is currently running.
+// if it is false the node has crashed.
+func (fm *runOmegaFailureManager[T]) CorrectNodes() map[int]bool {
+	return fm.correct
+}
+
+// Perform the crash of the node with the provided id.
+//
+// If the node is the trusted leader the pending changes are removed and the leader is changed to one of the correct nodes.
+// Otherwise the pending change to the node is removed.
+// The method is called by the CrashEvent when it is executed.
+func (fm *runOmegaFailureManager[T]) nodeCrash(nodeId int) error {
+	node, ok := fm.nodes[nodeId]
+	if !ok {
+		return errors.New("FailureManager: Received NodeCrash for node that is not added to the system")
+	}
+
+	if status := fm.correct[nodeId]; !status {
+		return errors.New("FailureManager: Received NodeCrash for node that has already crashed. Is failStop abstraction so node can not crash again.")
+	}
+	fm.correct[nodeId] = false
+
+	fm.crashFunc(node)
+
+	for id, f := range fm.failureCallback {
+		fm.ea.AddEvent(event.NewCrashDetection(
+			id,
+			nodeId,
+			f,
+		))
+	}
+
+	if nodeId == fm.leader {
+		// Invalidate the pending changes and force a change to one of the correct nodes
+		fm.removeLeaderChanges(func(int) bool { return true })
+		fm.epoch++
+		fm.scheduleLeaderChanges()
+	} else {
+		fm.removeLeaderChanges(func(target int) bool { return target == nodeId })
+	}
+	return nil
+}
+
+// Schedule a change of leader to each of the correct nodes that is not the trusted leader
+func (fm *runOmegaFailureManager[T]) scheduleLeaderChanges() {
+	ids := maps.Keys(fm.correct)
+	slices.Sort(ids)
+	for _, id := range ids {
+		if !fm.correct[id] || id == fm.leader {
+			continue
+		}
+		fm.pending[id] = true
+		fm.ea.AddEvent(event.NewLeaderChange(id, fm.epoch, fm.changeLeader))
+	}
+}
+
+// Remove the pending leader changes of the current epoch with a target that matches the filter.
+//
+// If the EventAdder does not support removing events, the changes are ignored when they are executed.
+func (fm *runOmegaFailureManager[T]) removeLeaderChanges(filter func(target int) bool) {
+	for target := range fm.pending {
+		if !filter(target) {
+			continue
+		}
+		delete(fm.pending, target)
+		if r, ok := fm.ea.(eventManager.EventRemover); ok {
+			r.RemoveEvent(event.NewLeaderChange(target, fm.epoch, nil).Id())
+		}
+	}
+}
+
+// Change the trusted leader.
+//
+// The change is ignored if another change has happened since it was scheduled, or if the new leader has crashed.
+// Changes made while the trusted leader is correct are counted against the bound of leader changes.
+// The other pending changes lost the race and are removed, and new changes are only scheduled if the bound has not been reached.
+// The method is called by the LeaderChange event when it is executed.
+func (fm *runOmegaFailureManager[T]) changeLeader(leader int, epoch int) error {
+	if epoch != fm.epoch || !fm.correct[leader] {
+		return nil
+	}
+	if fm.correct[fm.leader] {
+		fm.leaderChanges++
+	}
+	delete(fm.pending, leader)
+	fm.removeLeaderChanges(func(int) bool { return true })
+	fm.leader = leader
+	fm.epoch++
+
+	fm.notifyLeader()
+	if fm.leaderChanges < fm.maxLeaderChanges {
+		fm.scheduleLeaderChanges()
+	}
+	return nil
+}
+
+// Notify all subscribed nodes of the trusted leader
+//
+// The notification is discarded if the leader has changed before it is delivered.
+func (fm *runOmegaFailureManager[T]) notifyLeader() {
+	epoch := fm.epoch
+	for id, f := range fm.leaderCallback {
+		f := f
+		fm.ea.AddEvent(event.NewLeaderNotification(id, fm.leader, epoch, func(leader int) {
+			if epoch == fm.epoch {
+				f(leader)
+			}
+		}))
+	}
+}
+
+// Subscribe to updates about node status.
+//
+// id is the id of the node that subscribes to the callback.
+// The callback is a function that is called with the new status of the node when the status.
+func (fm *runOmegaFailureManager[T]) Subscribe(id int, callback func(int, bool)) {
+	fm.failureCallback[id] = callback
+}
+
+// Subscribe to leader changes.
+//
+// id is the id of the node that subscribes to the callback.
+// The callback is called with the id of the trusted leader when the node learns that the leader has changed.
+// The node is notified of the initial leader when the run starts.
+func (fm *runOmegaFailureManager[T]) LeaderSubscribe(id int, callback func(leader int)) {
+	fm.leaderCallback[id] = callback
+}
@@ -0,0 +1,86 @@
+package failureManager
+
+import (
+	"gomc/event"
+	"testing"
+)
+
+func TestOmegaLeaderChange(t *testing.T) {
+	sch := NewMockRunScheduler()
+	fm := newRunOmegaFailureManager(sch, func(t *MockNode) { t.crashed = true }, 1, []int{})
+	leaders := []int{}
+	fm.LeaderSubscribe(0, func(leader int) { leaders = append(leaders, leader) })
+	fm.Init(map[int]*MockNode{0: {}, 1: {}, 2: {}})
+
+	// The initial leader is notified and a change to each of the other nodes is scheduled
+	executeFirst[event.LeaderNotification](t, sch)
+	if len(leaders) != 1 || leaders[0] != 0 {
+		t.Errorf("Expected node 0 to be the initial leader. Got: %v", leaders)
+	}
+	if n := countEvents[event.LeaderChange](sch); n != 2 {
+		t.Fatalf("Expected 2 leader changes to be scheduled. Got: %v", sch.addedEvents)
+	}
+
+	executeFirst[event.LeaderChange](t, sch)
+	executeFirst[event.LeaderNotification](t, sch)
+	if len(leaders) != 2 || leaders[1] != 1 {
+		t.Errorf("Expected node 1 to be the new leader. Got: %v", leaders)
+	}
+
+	// The other pending change is no longer valid and is removed, and the maximum number of changes has been reached
+	if n := countEvents[event.LeaderChange](sch); n != 0 {
+		t.Errorf("Expected the pending leader change to be removed and no more changes to be scheduled. Got: %v", sch.addedEvents)
+	}
+}
+
+func TestOmegaRemoveStaleChanges(t *testing.T) {
+	sch := NewMockRunScheduler()
+	fm := newRunOmegaFailureManager(sch, func(t *MockNode) { t.crashed = true }, 1, []int{0, 2})
+	fm.Init(map[int]*MockNode{0: {}, 1: {}, 2: {}, 3: {}})
+	if n := countEvents[event.LeaderChange](sch); n != 3 {
+		t.Fatalf("Expected 3 leader changes to be scheduled. Got: %v", sch.addedEvents)
+	}
+
+	// The change to a crashed node is removed
+	executeFirst[event.CrashEvent](t, sch)
+	executeFirst[event.CrashEvent](t, sch)
+	for _, evt := range sch.addedEvents {
+		if lc, ok := evt.(event.LeaderChange); ok && lc.Target() == 2 {
+			t.Errorf("Expected the change to the crashed node to be removed. Got: %v", sch.addedEvents)
+		}
+	}
+
+	// The leader crashed, so the changes of the previous epoch are removed and a change to each correct node is scheduled
+	if fm.leader != 0 || fm.correct[0] {
+		t.Fatalf("Expected the crashed node 0 to be the leader")
+	}
+	if n := countEvents[event.LeaderChange](sch); n != 2 {
+		t.Errorf("Expected only the changes of the current epoch to be pending. Got: %v", sch.addedEvents)
+	}
+	for _, evt := range sch.addedEvents {
+		if lc, ok := evt.(event.LeaderChange); ok && lc.Id() == event.NewLeaderChange(lc.Target(), 0, nil).Id() {
+			t.Errorf("Expected the changes of the previous epoch to be removed. Got: %v", sch.addedEvents)
+		}
+	}
+}
+
+func TestOmegaLeaderCrash(t *testing.T) {
+	sch := NewMockRunScheduler()
+	fm := newRunOmegaFailureManager(sch, func(t *MockNode) { t.crashed = true }, 0, []int{0})
+	fm.LeaderSubscribe(1, func(leader int) {})
+	fm.Init(map[int]*MockNode{0: {}, 1: {}, 2: {}})
+
+	if n := countEvents[event.LeaderChange](sch); n != 0 {
+		t.Errorf("Did not expect leader changes to be scheduled when maxLeaderChanges is 0. Got: %v", sch.addedEvents)
+	}
+
+	// The leader crashes. The leader is changed to one of the correct nodes even though the bound is reached
+	executeFirst[event.CrashEvent](t, sch)
+	if n := countEvents[event.LeaderChange](sch); n != 2 {
+		t.Fatalf("Expected a change to each of the correct nodes to be scheduled. Got: %v", sch.addedEvents)
+	}
+	executeFirst[event.LeaderChange](t, sch)
+	if fm.leader != 1 {
+		t.Errorf("Expected node 1 to be the new leader. Got: %v", fm.leader)
+	}
+}
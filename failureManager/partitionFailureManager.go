@@ -0,0 +1,256 @@
+package failureManager
+
+import (
+	"errors"
+	"gomc/event"
+	"gomc/eventManager"
+
+	"golang.org/x/exp/slices"
+)
+
+// The PartitionFailureManager is a failure manager that partitions the network in addition to crash-stop failures.
+//
+// Crashed nodes are detected by all nodes subscribed to crash updates, as with the PerfectFailureManager.
+//
+// It is configured with a slice of partitions. Each partition is a slice of groups of node ids.
+// Nodes that are not in any of the groups form a group together.
+// At most one partition is active at a time. The partition is active from the Partition event is executed until the Heal event is executed.
+// While a partition is active, messages between nodes in different groups are dropped.
+// The number of partitions in a run is bounded by maxPartitions, to keep the state space finite.
+// Pending partitions are removed while a partition is active, if the EventAdder supports removing events, and are scheduled again when the partition heals.
+type PartitionFailureManager[T any] struct {
+	crashFunc     func(*T)
+	partitions    [][][]int
+	maxPartitions int
+	failingNodes  []int
+}
+
+// Create a new PartitionFailureManager
+//
+// crashFunc is a function performing the crash on the node.
+// It should close all network connections and stop all ongoing executions on the node.
+// Events executed on the node after the crash should have no effect.
+// partitions is a slice of the partitions of the network that can occur, where each partition is a slice of groups of node ids.
+// maxPartitions is the maximum number of partitions in a run.
+// failingNodes is a slice of node ids of the nodes that will crash at some point during a run.
+func NewPartitionFailureManager[T any](crashFunc func(*T), partitions [][][]int, maxPartitions int, failingNodes []int) *PartitionFailureManager[T] {
+	return &PartitionFailureManager[T]{
+		crashFunc:     crashFunc,
+		partitions:    partitions,
+		maxPartitions: maxPartitions,
+		failingNodes:  failingNodes,
+	}
+}
+
+// Create a RunFailureManager that can be used when simulating a run
+// ea is the EventAdder that is used in this run.
+// The EventAdder for the run is provided in the SimulationParameters
+func (pfm PartitionFailureManager[T]) GetRunFailureManager(ea eventManager.EventAdder) RunFailureManager[T] {
+	return newRunPartitionFailureManager(ea, pfm.crashFunc, pfm.partitions, pfm.maxPartitions, pfm.failingNodes)
+}
+
+// The run specific implementation of the PartitionFailureManager
+//
+// Manages the functionality of the PartitionFailureManager during the simulation of a run.
+type runPartitionFailureManager[T any] struct {
+	ea            eventManager.EventAdder
+	crashFunc     func(*T)
+	partitions    [][][]int
+	maxPartitions int
+	failingNodes  []int
+
+	correct         map[int]bool
+	nodes           map[int]*T
+	failureCallback map[int]func(int, bool)
+
+	// The group of each node in the active partition. Is nil if no partition is active
+	partition map[int]int
+	// The number of partitions in the current run
+	numPartitions int
+	// The partitions that have a pending Partition event
+	pending map[int]bool
+}
+
+// Create a new runPartitionFailureManager
+func newRunPartitionFailureManager[T any](ea eventManager.EventAdder, crashFunc func(*T), partitions [][][]int, maxPartitions int, failingNodes []int) *runPartitionFailureManager[T] {
+	return &runPartitionFailureManager[T]{
+		ea:            ea,
+		crashFunc:     crashFunc,
+		partitions:    partitions,
+		maxPartitions: maxPartitions,
+		failingNodes:  failingNodes,
+
+		correct:         make(map[int]bool),
+		failureCallback: make(map[int]func(int, bool)),
+		pending:         make(map[int]bool),
+	}
+}
+
+// Initialize the FailureManager with the nodes that are used in this run
+//
+// Schedules the crashes of the failing nodes and the partitions of the network.
+func (fm *runPartitionFailureManager[T]) Init(nodes map[int]*T) {
+	for id := range nodes {
+		fm.correct[id] = true
+	}
+	fm.nodes = nodes
+	fm.partition = nil
+	fm.numPartitions = 0
+	fm.pending = make(map[int]bool)
+
+	for _, id := range fm.failingNodes {
+		if _, ok := nodes[id]; !ok {
+			continue
+		}
+		fm.ea.AddEvent(
+			event.NewCrashEvent(id, fm.nodeCrash),
+		)
+	}
+
+	if fm.maxPartitions > 0 {
+		fm.schedulePartitions()
+	}
+}
+
+// Return a map of the node ids and the status of the corresponding node
+//
+// If the status is true the node is currently running.
+// if it is false the node has crashed.
+func (fm *runPartitionFailureManager[T]) CorrectNodes() map[int]bool {
+	return fm.correct
+}
+
+// Return a map of the node ids and the group of the corresponding node in the active partition.
+//
+// Returns nil if the network is not partitioned.
+func (fm *runPartitionFailureManager[T]) Partition() map[int]int {
+	return fm.partition
+}
+
+// Perform the crash of the node with the provided id.
+//
+// The method is called by the CrashEvent when it is executed.
+func (fm *runPartitionFailureManager[T]) nodeCrash(nodeId int) error {
+	node, ok := fm.nodes[nodeId]
+	if !ok {
+		return errors.New("FailureManager: Received NodeCrash for node that is not added to the system")
+	}
+
+	if status := fm.correct[nodeId]; !status {
+		return errors.New("FailureManager: Received NodeCrash for node that has already crashed. Is failStop abstraction so node can not crash again.")
+	}
+	fm.correct[nodeId] = false
+
+	fm.crashFunc(node)
+
+	for id, f := range fm.failureCallback {
+		fm.ea.AddEvent(event.NewCrashDetection(
+			id,
+			nodeId,
+			f,
+		))
+	}
+	return nil
+}
+
+// Schedule a Partition event for each of the partitions that does not have a pending Partition event
+func (fm *runPartitionFailureManager[T]) schedulePartitions() {
+	for i := range fm.partitions {
+		if fm.pending[i] {
+			continue
+		}
+		target, ok := fm.partitionTarget(i)
+		if !ok {
+			continue
+		}
+		fm.pending[i] = true
+		fm.ea.AddEvent(event.NewPartitionEvent(target, i, fm.partitionNetwork))
+	}
+}
+
+// Returns the lowest id of the nodes in the run, which is used as the target of the events of the partition.
+//
+// Returns false if the partition does not contain any of the nodes in the run.
+func (fm *runPartitionFailureManager[T]) partitionTarget(partition int) (int, bool) {
+	ids := []int{}
+	for _, group := range fm.partitions[partition] {
+		for _, id := range group {
+			if _, ok := fm.nodes[id]; ok {
+				ids = append(ids, id)
+			}
+		}
+	}
+	if len(ids) == 0 {
+		return 0, false
+	}
+	slices.Sort(ids)
+	return ids[0], true
+}
+
+// Activate the partition with the provided index.
+//
+// The partition is ignored if another partition is active or if the maximum number of partitions has been reached.
+// Otherwise the Heal event of the partition is scheduled, and the pending partitions are removed since they can not happen while the partition is active.
+// The method is called by the PartitionEvent when it is executed.
+func (fm *runPartitionFailureManager[T]) partitionNetwork(partition int) error {
+	delete(fm.pending, partition)
+	if fm.partition != nil || fm.numPartitions >= fm.maxPartitions {
+		return nil
+	}
+	fm.numPartitions++
+
+	// Nodes not in any group form the group after the last configured group
+	groups := fm.partitions[partition]
+	fm.partition = make(map[int]int)
+	for id := range fm.nodes {
+		fm.partition[id] = len(groups)
+	}
+	for i, group := range groups {
+		for _, id := range group {
+			if _, ok := fm.nodes[id]; ok {
+				fm.partition[id] = i
+			}
+		}
+	}
+
+	fm.removePartitions()
+	target, _ := fm.partitionTarget(partition)
+	fm.ea.AddEvent(event.NewHealEvent(target, partition, fm.heal))
+	return nil
+}
+
+// Remove the pending Partition events.
+//
+// If the EventAdder does not support removing events, the partitions are ignored when they are executed.
+func (fm *runPartitionFailureManager[T]) removePartitions() {
+	for partition := range fm.pending {
+		delete(fm.pending, partition)
+		if r, ok := fm.ea.(eventManager.EventRemover); ok {
+			target, _ := fm.partitionTarget(partition)
+			r.RemoveEvent(event.NewPartitionEvent(target, partition, nil).Id())
+		}
+	}
+}
+
+// Heal the active partition.
+//
+// New partitions are scheduled if the maximum number of partitions has not been reached.
+// The method is called by the Heal event when it is executed.
+func (fm *runPartitionFailureManager[T]) heal(partition int) error {
+	if fm.partition == nil {
+		return errors.New("FailureManager: Received Heal when the network is not partitioned")
+	}
+	fm.partition = nil
+	if fm.numPartitions < fm.maxPartitions {
+		fm.schedulePartitions()
+	}
+	return nil
+}
+
+// Subscribe to updates about node status.
+//
+// id is the id of the node that subscribes to the callback.
+// The callback is a function that is called with the new status of the node when the status.
+func (fm *runPartitionFailureManager[T]) Subscribe(id int, callback func(int, bool)) {
+	fm.failureCallback[id] = callback
+}
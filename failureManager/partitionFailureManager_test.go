@@ -0,0 +1,74 @@
+package failureManager
+
+import (
+	"gomc/event"
+	"testing"
+
+	"golang.org/x/exp/maps"
+)
+
+func TestPartitionFailureManager(t *testing.T) {
+	sch := NewMockRunScheduler()
+	partitions := [][][]int{{{0}}, {{0, 1}, {2}}}
+	fm := newRunPartitionFailureManager(sch, func(t *MockNode) { t.crashed = true }, partitions, 2, []int{})
+	fm.Init(map[int]*MockNode{0: {}, 1: {}, 2: {}})
+
+	if n := countEvents[event.PartitionEvent](sch); n != 2 {
+		t.Fatalf("Expected 2 partitions to be scheduled. Got: %v", sch.addedEvents)
+	}
+	if fm.Partition() != nil {
+		t.Errorf("Did not expect the network to be partitioned before a partition event. Got: %v", fm.Partition())
+	}
+
+	// Node 0 is isolated from the rest of the nodes
+	executeFirst[event.PartitionEvent](t, sch)
+	expected := map[int]int{0: 0, 1: 1, 2: 1}
+	if !maps.Equal(fm.Partition(), expected) {
+		t.Errorf("Unexpected partition. Got: %v. Expected: %v", fm.Partition(), expected)
+	}
+
+	// Only one partition can be active at a time. The other partition is removed and only the Heal event is pending
+	if n := countEvents[event.PartitionEvent](sch); n != 1 || sch.addedEvents[0].Id() != event.NewHealEvent(0, 0, nil).Id() {
+		t.Fatalf("Expected the pending partition to be removed. Got: %v", sch.addedEvents)
+	}
+
+	// Heal the partition. Both partitions can occur again
+	executeFirst[event.PartitionEvent](t, sch)
+	if fm.Partition() != nil {
+		t.Errorf("Expected the partition to be healed. Got: %v", fm.Partition())
+	}
+	if n := countEvents[event.PartitionEvent](sch); n != 2 {
+		t.Fatalf("Expected 2 partitions to be scheduled. Got: %v", sch.addedEvents)
+	}
+
+	// The maximum number of partitions is reached after the next partition, and the other partition is removed
+	executeFirst[event.PartitionEvent](t, sch)
+	if n := countEvents[event.PartitionEvent](sch); n != 1 {
+		t.Fatalf("Expected only the Heal event to be pending. Got: %v", sch.addedEvents)
+	}
+	executeFirst[event.PartitionEvent](t, sch)
+	if fm.Partition() != nil {
+		t.Errorf("Expected the partition to be healed. Got: %v", fm.Partition())
+	}
+	if n := countEvents[event.PartitionEvent](sch); n != 0 {
+		t.Errorf("Did not expect more partitions to be scheduled. Got: %v", sch.addedEvents)
+	}
+}
+
+func TestPartitionFailureManagerReset(t *testing.T) {
+	sch := NewMockRunScheduler()
+	fm := newRunPartitionFailureManager(sch, func(t *MockNode) { t.crashed = true }, [][][]int{{{0}}}, 1, []int{})
+	nodes := map[int]*MockNode{0: {}, 1: {}}
+	fm.Init(nodes)
+	executeFirst[event.PartitionEvent](t, sch)
+
+	// The partition is not carried over to the next run
+	sch.addedEvents = nil
+	fm.Init(nodes)
+	if fm.Partition() != nil {
+		t.Errorf("Expected the network not to be partitioned at the start of a run. Got: %v", fm.Partition())
+	}
+	if n := countEvents[event.PartitionEvent](sch); n != 1 {
+		t.Errorf("Expected the partition to be scheduled. Got: %v", sch.addedEvents)
+	}
+}
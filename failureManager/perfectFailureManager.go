@@ -4,15 +4,25 @@ import (
 	"errors"
 	"gomc/event"
 	"gomc/eventManager"
+
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
 )
 
 // The PerfectFailureManager is a failure manager that implements the PerfectFailureDetector abstraction in a fail-stop system.
 //
 // It is configured with a slice of nodes that will crash at some point during the simulation, i.e. nodes that are faulty.
 // it is also configured with a function specifying how the node should crash.
+//
+// If the PerfectFailureManager is bounded, the crash of each of the failing nodes is a nondeterministic choice, and at most maxCrashes nodes crash in a run.
+// Each failing node is then either crashed by a CrashEvent or survives the run by a SurviveEvent,
+// and all combinations of zero to maxCrashes crashes are explored in a single simulation.
 type PerfectFailureManager[T any] struct {
 	crashFunc    func(*T)
 	failingNodes []int
+
+	bounded    bool
+	maxCrashes int
 }
 
 // Create a new PerfectFailureManager
@@ -29,11 +39,32 @@ func NewPerfectFailureManager[T any](crashFunc func(*T), failingNodes []int) *Pe
 	}
 }
 
+// Create a new bounded PerfectFailureManager
+//
+// Implements the PerfectFailureDetector abstraction in a fail-stop system, where any maxCrashes of the failing nodes can crash.
+// crashFunc is a function performing the crash on the node.
+// It should close all network connections and stop all ongoing executions on the node.
+// Events executed on the node after the crash should have no effect.
+// maxCrashes is the maximum number of nodes that crash in a run.
+// failingNodes is a slice of node ids of the nodes that can crash. If it is empty, all nodes can crash.
+func NewBoundedPerfectFailureManager[T any](crashFunc func(*T), maxCrashes int, failingNodes []int) *PerfectFailureManager[T] {
+	return &PerfectFailureManager[T]{
+		crashFunc:    crashFunc,
+		failingNodes: failingNodes,
+
+		bounded:    true,
+		maxCrashes: maxCrashes,
+	}
+}
+
 // Create a RunFailureManager that can be used when simulating a run
 // ea is the EventAdder that is used in this run.
 // The EventAdder for the run is provided in the SimulationParameters
 func (pfm PerfectFailureManager[T]) GetRunFailureManager(ea eventManager.EventAdder) RunFailureManager[T] {
-	return newRunPerfectFailureManager(ea, pfm.crashFunc, pfm.failingNodes)
+	fm := newRunPerfectFailureManager(ea, pfm.crashFunc, pfm.failingNodes)
+	fm.bounded = pfm.bounded
+	fm.maxCrashes = pfm.maxCrashes
+	return fm
 }
 
 // The run specific implementation of the PerfectFailureManager
@@ -47,6 +78,14 @@ type runPerfectFailureManager[T any] struct {
 	correct         map[int]bool
 	nodes           map[int]*T
 	failureCallback map[int]func(int, bool)
+
+	// If true, at most maxCrashes of the failing nodes crash in a run
+	bounded    bool
+	maxCrashes int
+	// The number of crashes in the current run
+	crashes int
+	// The failing nodes that have not yet crashed or survived in the current run
+	undecided map[int]bool
 }
 
 // Create a new runPerfectFailureManager
@@ -72,15 +111,32 @@ func (fm *runPerfectFailureManager[T]) Init(nodes map[int]*T) {
 	}
 
 	fm.nodes = nodes
+	fm.crashes = 0
+	fm.undecided = make(map[int]bool)
+
+	failingNodes := fm.failingNodes
+	if fm.bounded {
+		if fm.maxCrashes < 1 {
+			return
+		}
+		if len(failingNodes) == 0 {
+			failingNodes = maps.Keys(nodes)
+			slices.Sort(failingNodes)
+		}
+	}
 
 	// Schedule crash events
-	for _, id := range fm.failingNodes {
+	for _, id := range failingNodes {
 		if _, ok := nodes[id]; !ok {
 			continue
 		}
 		fm.ea.AddEvent(
 			event.NewCrashEvent(id, fm.nodeCrash),
 		)
+		if fm.bounded {
+			fm.undecided[id] = true
+			fm.ea.AddEvent(event.NewSurviveEvent(id, fm.nodeSurvive))
+		}
 	}
 }
 
@@ -94,6 +150,7 @@ func (fm *runPerfectFailureManager[T]) CorrectNodes() map[int]bool {
 
 // Perform the crash of the node with the provided id.
 //
+// If the failure manager is bounded, the crash is ignored if the node has survived or maxCrashes nodes has already crashed in the run.
 // The method is called by the CrashEvent when it is executed.
 func (fm *runPerfectFailureManager[T]) nodeCrash(nodeId int) error {
 	node, ok := fm.nodes[nodeId]
@@ -104,6 +161,20 @@ func (fm *runPerfectFailureManager[T]) nodeCrash(nodeId int) error {
 	if status := fm.correct[nodeId]; !status {
 		return errors.New("FailureManager: Received NodeCrash for node that has already crashed. Is failStop abstraction so node can not crash again.")
 	}
+	if fm.bounded {
+		if !fm.undecided[nodeId] {
+			return nil
+		}
+		fm.decide(nodeId)
+		if fm.crashes+1 >= fm.maxCrashes {
+			// The budget is used, so the remaining failing nodes survive the run
+			for id := range fm.undecided {
+				fm.decide(id)
+			}
+		}
+	}
+	fm.crashes++
+
 	// Set node as crashed
 	fm.correct[nodeId] = false
 
@@ -121,6 +192,26 @@ func (fm *runPerfectFailureManager[T]) nodeCrash(nodeId int) error {
 	return nil
 }
 
+// Let the node survive the run.
+//
+// The method is called by the SurviveEvent when it is executed.
+func (fm *runPerfectFailureManager[T]) nodeSurvive(nodeId int) {
+	if fm.undecided[nodeId] {
+		fm.decide(nodeId)
+	}
+}
+
+// Mark that the node has crashed or survived, and remove the pending CrashEvent and SurviveEvent of the node.
+//
+// If the EventAdder does not support removing events, the events have no effect when they are executed.
+func (fm *runPerfectFailureManager[T]) decide(nodeId int) {
+	delete(fm.undecided, nodeId)
+	if r, ok := fm.ea.(eventManager.EventRemover); ok {
+		r.RemoveEvent(event.NewCrashEvent(nodeId, nil).Id())
+		r.RemoveEvent(event.NewSurviveEvent(nodeId, nil).Id())
+	}
+}
+
 // Subscribe to updates about node status.
 //
 // id is the id of the node that subscribes to the callback.
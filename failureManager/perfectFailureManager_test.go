@@ -177,3 +177,53 @@ var NodeCrashTest = []struct {
 		true,
 	},
 }
+
+func TestBoundedCrashes(t *testing.T) {
+	sch := NewMockRunScheduler()
+	pfm := NewBoundedPerfectFailureManager(func(t *MockNode) { t.crashed = true }, 1, []int{})
+	fm := pfm.GetRunFailureManager(sch)
+	nodes := map[int]*MockNode{0: {}, 1: {}, 2: {}}
+	fm.Init(nodes)
+
+	// Any of the nodes can crash or survive
+	if n := countEvents[event.CrashEvent](sch); n != 3 {
+		t.Fatalf("Expected a crash to be scheduled for each node. Got: %v", sch.addedEvents)
+	}
+	if n := countEvents[event.SurviveEvent](sch); n != 3 {
+		t.Fatalf("Expected a survival to be scheduled for each node. Got: %v", sch.addedEvents)
+	}
+
+	// The remaining alternatives are removed once the budget is used
+	executeFirst[event.CrashEvent](t, sch)
+	if len(sch.addedEvents) != 0 {
+		t.Errorf("Expected the remaining crashes and survivals to be removed. Got: %v", sch.addedEvents)
+	}
+	expected := map[int]bool{0: false, 1: true, 2: true}
+	if !maps.Equal(fm.CorrectNodes(), expected) {
+		t.Errorf("Expected only one node to crash. Expected: %v. Got: %v", expected, fm.CorrectNodes())
+	}
+	if !nodes[0].crashed || nodes[1].crashed {
+		t.Errorf("Expected the crash function to only be called on the crashed node")
+	}
+
+	// The number of crashes is reset between runs
+	sch.addedEvents = nil
+	fm.Init(map[int]*MockNode{0: {}, 1: {}, 2: {}})
+	executeFirst[event.CrashEvent](t, sch)
+	if fm.CorrectNodes()[0] {
+		t.Errorf("Expected the node to crash in the new run. Got: %v", fm.CorrectNodes())
+	}
+
+	// A node that survives can not crash, and the other nodes can still crash
+	sch.addedEvents = nil
+	fm.Init(map[int]*MockNode{0: {}, 1: {}, 2: {}})
+	executeFirst[event.SurviveEvent](t, sch)
+	if n := countEvents[event.CrashEvent](sch); n != 2 {
+		t.Errorf("Expected the crash of the surviving node to be removed. Got: %v", sch.addedEvents)
+	}
+	executeFirst[event.CrashEvent](t, sch)
+	expected = map[int]bool{0: true, 1: false, 2: true}
+	if !maps.Equal(fm.CorrectNodes(), expected) {
+		t.Errorf("Expected node 1 to crash. Expected: %v. Got: %v", expected, fm.CorrectNodes())
+	}
+}
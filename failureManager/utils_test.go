@@ -58,6 +58,16 @@ func (ms *MockRunScheduler) AddEvent(evt event.Event) {
 	ms.addedEvents = append(ms.addedEvents, evt)
 }
 
+func (ms *MockRunScheduler) RemoveEvent(id event.EventId) bool {
+	for i, evt := range ms.addedEvents {
+		if evt.Id() == id {
+			ms.addedEvents = append(ms.addedEvents[:i], ms.addedEvents[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
 func (ms *MockRunScheduler) GetEvent() (event.Event, error) {
 	if ms.index < len(ms.eventQueue) {
 		evt := ms.eventQueue[ms.index]
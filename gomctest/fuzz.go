@@ -0,0 +1,118 @@
+package gomctest
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"gomc"
+	"gomc/replay"
+)
+
+// The input of a single fuzzed run.
+//
+// The request generator consumes bytes from the start of the input to decide the parameters of the requests.
+// The bytes that remain after the requests have been generated decide the scheduling choices of the run.
+// When the input is exhausted all methods return the zero value.
+type Input struct {
+	data []byte
+}
+
+// Create a new Input from the provided bytes
+func NewInput(data []byte) *Input {
+	return &Input{data: data}
+}
+
+// Consume a single byte of the input
+func (in *Input) Byte() byte {
+	if len(in.data) == 0 {
+		return 0
+	}
+	b := in.data[0]
+	in.data = in.data[1:]
+	return b
+}
+
+// Consume a byte of the input and return it as a boolean
+func (in *Input) Bool() bool {
+	return in.Byte()%2 == 1
+}
+
+// Consume bytes of the input and return an int in the range [0, n).
+//
+// Panics if n <= 0.
+func (in *Input) Intn(n int) int {
+	if n <= 0 {
+		panic("gomctest: Intn called with n <= 0")
+	}
+	return int(in.Uint32() % uint32(n))
+}
+
+// Consume four bytes of the input and return them as an uint32
+func (in *Input) Uint32() uint32 {
+	var buf [4]byte
+	for i := range buf {
+		buf[i] = in.Byte()
+	}
+	return binary.LittleEndian.Uint32(buf[:])
+}
+
+// Consume at most n bytes of the input
+func (in *Input) Bytes(n int) []byte {
+	if n > len(in.data) {
+		n = len(in.data)
+	}
+	b := in.data[:n]
+	in.data = in.data[n:]
+	return b
+}
+
+// The bytes of the input that have not been consumed
+func (in *Input) Remaining() []byte {
+	return in.data
+}
+
+// Register a fuzz target that simulates one run for each input.
+//
+// The input decides both the parameters of the requests and the scheduling choices of the run.
+// requests creates the RequestOption used for the run by consuming bytes from the Input.
+// The remaining bytes are used to schedule the run using a bytes scheduler.
+// Seeds can be added to the corpus using f.Add([]byte) before calling Fuzz.
+//
+// When a property is violated the test fails with a description of the run that violated the property.
+// The failing input is saved by the go fuzzer, and the run is saved as a replay artifact under the testdata directory so that it can be replayed using gomc.ReplayArtifact.
+// The configuration of the artifact is described using gomc.ScenarioConfig.
+//
+// The simulation is reused for all inputs. The other parameters are the same as the parameters used by Simulation.Run.
+func Fuzz[T, S any](f *testing.F, sim gomc.Simulation[T, S], initNodes gomc.InitNodeOption[T], requests func(in *Input) gomc.RequestOption, checker gomc.CheckerOption[S], opts ...gomc.RunOptions) {
+	f.Helper()
+	f.Add([]byte{})
+	f.Fuzz(func(t *testing.T, data []byte) {
+		checkInput(t, data, sim, initNodes, requests, checker, opts...)
+	})
+}
+
+// Simulate the run decided by the input and fail the test if some property is violated.
+//
+// Returns true if all properties hold, false otherwise.
+func checkInput[T, S any](t testing.TB, data []byte, sim gomc.Simulation[T, S], initNodes gomc.InitNodeOption[T], requests func(in *Input) gomc.RequestOption, checker gomc.CheckerOption[S], opts ...gomc.RunOptions) bool {
+	t.Helper()
+
+	in := NewInput(data)
+	reqs := requests(in)
+	resp, err := runSimulation(sim, initNodes, reqs, checker, append(append([]gomc.RunOptions{}, opts...), gomc.BytesRun(in.Remaining()))...)
+	if err != nil {
+		t.Errorf("gomctest: Unable to complete the simulation: %v", err)
+		return false
+	}
+	ok, desc := resp.Response()
+	if ok {
+		return true
+	}
+
+	path := ReplayPath(t)
+	if err := saveArtifact(path, replay.New(gomc.ScenarioConfig(initNodes, reqs, checker, opts...), resp)); err != nil {
+		t.Errorf("gomctest: Unable to save the counterexample to %v: %v", path, err)
+	}
+	t.Errorf("gomctest: A property was violated.\n%v", formatCounterexample(desc, resp.Export()))
+	return false
+}
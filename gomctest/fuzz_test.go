@@ -0,0 +1,92 @@
+package gomctest
+
+import (
+	"os"
+	"testing"
+
+	"gomc"
+	"gomc/checking"
+	"gomc/eventManager"
+	"gomc/replay"
+)
+
+func prepareFuzz() (gomc.Simulation[node, int], gomc.InitNodeOption[node], func(in *Input) gomc.RequestOption) {
+	sim := gomc.PrepareSimulation(
+		gomc.WithTreeStateManager(
+			func(n *node) int { return n.val },
+			func(a, b int) bool { return a == b },
+		),
+	)
+	nodeIds := []int{0, 1}
+	initNodes := gomc.InitSingleNode(nodeIds, func(id int, sp eventManager.SimulationParameters) *node {
+		return &node{id: id, send: eventManager.NewSender(sp).SendFunc(id), nodes: nodeIds}
+	})
+	requests := func(in *Input) gomc.RequestOption {
+		return gomc.WithRequests(
+			gomc.NewRequest(0, "Set", in.Intn(4)),
+			gomc.NewRequest(1, "Set", in.Intn(4)),
+		)
+	}
+	return sim, initNodes, requests
+}
+
+func maxValChecker(maxVal int) gomc.CheckerOption[int] {
+	return gomc.WithPredicateChecker(func(s checking.State[int]) bool {
+		return checking.ForAllNodes(func(val int) bool { return val <= maxVal }, s, false)
+	})
+}
+
+func FuzzCheck(f *testing.F) {
+	f.Add([]byte{1, 0, 0, 0, 2, 0, 0, 0, 1, 0, 1})
+	sim, initNodes, requests := prepareFuzz()
+	Fuzz(f, sim, initNodes, requests, maxValChecker(3))
+}
+
+func TestCheckInput(t *testing.T) {
+	wd, _ := os.Getwd()
+	defer os.Chdir(wd)
+	os.Chdir(t.TempDir())
+
+	sim, initNodes, requests := prepareFuzz()
+
+	rec := &recorder{TB: t, name: "FuzzPass"}
+	if !checkInput(rec, []byte{2, 0, 0, 0, 1, 0, 0, 0, 3, 2, 1}, sim, initNodes, requests, maxValChecker(2)) {
+		t.Errorf("Expected the check to pass. Got: %v", rec.errors)
+	}
+
+	rec = &recorder{TB: t, name: "FuzzFail"}
+	if checkInput(rec, []byte{3, 0, 0, 0}, sim, initNodes, requests, maxValChecker(2)) {
+		t.Errorf("Expected the check to fail")
+	}
+	f, err := os.Open(ReplayPath(rec))
+	if err != nil {
+		t.Fatalf("Expected the counterexample to be saved. Got: %v", err)
+	}
+	defer f.Close()
+	artifact, err := replay.Read(f)
+	if err != nil {
+		t.Fatalf("Expected the counterexample to be saved as a replay artifact. Got: %v", err)
+	}
+	if len(artifact.Run) == 0 {
+		t.Errorf("Expected the saved run to contain events")
+	}
+}
+
+func TestInput(t *testing.T) {
+	in := NewInput([]byte{5, 1, 0, 0, 0, 7, 8})
+	if b := in.Byte(); b != 5 {
+		t.Errorf("Unexpected byte. Got: %v. Expected: %v", b, 5)
+	}
+	if n := in.Intn(10); n != 1 {
+		t.Errorf("Unexpected int. Got: %v. Expected: %v", n, 1)
+	}
+	if !in.Bool() {
+		t.Errorf("Expected true")
+	}
+	if b := in.Bytes(5); len(b) != 1 || b[0] != 8 {
+		t.Errorf("Unexpected bytes. Got: %v. Expected: %v", b, []byte{8})
+	}
+	if in.Bool() || in.Intn(3) != 0 || len(in.Remaining()) != 0 {
+		t.Errorf("Expected zero values from an exhausted input")
+	}
+}
@@ -0,0 +1,124 @@
+// Helpers for using Go-MC from go tests.
+//
+// The helpers run a simulation and fail the test with a formatted counterexample if a property is violated.
+// The counterexample is saved as a replay artifact under the testdata directory, and is replayed before exploring the state space the next time the test is run.
+package gomctest
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gomc"
+	"gomc/checking"
+	"gomc/event"
+	"gomc/replay"
+	"gomc/scheduler"
+)
+
+// The directory that counterexamples are saved in.
+//
+// The path is relative to the directory of the package that is tested.
+const TestdataDir = "testdata"
+
+// Run the simulation and fail the test if some property is violated.
+//
+// If a counterexample has been saved by a previous run of the test it is replayed before exploring the state space.
+// If the counterexample still violates a property the test fails without exploring the state space.
+// If the counterexample was recorded using a different configuration, diverges from the run of the simulation or no longer violates a property it is removed.
+// If the replay fails with any other error the test fails and the counterexample is kept.
+//
+// cfg describes the configuration of the simulation, and is recorded in the counterexample and validated against it before it is replayed.
+// It should be created using gomc.ReplayConfig with the nodes, failing nodes, requests, seed and predicates of the simulation.
+// gomc.ScenarioConfig can be used if the nodes are created using InitSingleNode and the scheduler is not randomized.
+//
+// When a property is violated the test fails with a description of the run that violated the property,
+// and the run is saved under the testdata directory so that it can be replayed.
+//
+// The other parameters are the same as the parameters used by Simulation.Run.
+// Returns true if all properties hold, false otherwise.
+func Check[T, S any](t testing.TB, sim gomc.Simulation[T, S], cfg replay.Config, initNodes gomc.InitNodeOption[T], requests gomc.RequestOption, checker gomc.CheckerOption[S], opts ...gomc.RunOptions) bool {
+	t.Helper()
+
+	path := ReplayPath(t)
+	artifact, err := replay.Load(path, cfg)
+	if err == nil {
+		resp, err := runSimulation(sim, initNodes, requests, checker, append(append([]gomc.RunOptions{}, opts...), gomc.ReplayArtifact(artifact))...)
+		if errors.As(err, &scheduler.DivergenceError{}) {
+			t.Logf("gomctest: The counterexample saved in %v no longer can be replayed. Removing it. Error: %v", path, err)
+			os.Remove(path)
+		} else if err != nil {
+			t.Errorf("gomctest: Unable to replay the counterexample saved in %v: %v", path, err)
+			return false
+		} else if ok, desc := resp.Response(); !ok {
+			t.Errorf("gomctest: The counterexample saved in %v still violates a property.\n%v", path, formatCounterexample(desc, resp.Export()))
+			return false
+		} else {
+			t.Logf("gomctest: The counterexample saved in %v no longer violates a property. Removing it.", path)
+			os.Remove(path)
+		}
+	} else if errors.As(err, &replay.MismatchError{}) {
+		t.Logf("gomctest: The counterexample saved in %v was recorded using a different configuration. Removing it. Error: %v", path, err)
+		os.Remove(path)
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		t.Logf("gomctest: Unable to load the counterexample saved in %v: %v", path, err)
+	}
+
+	resp, err := runSimulation(sim, initNodes, requests, checker, opts...)
+	if err != nil {
+		t.Errorf("gomctest: Unable to complete the simulation: %v", err)
+		return false
+	}
+	ok, desc := resp.Response()
+	if ok {
+		return true
+	}
+
+	if err := saveArtifact(path, replay.New(cfg, resp)); err != nil {
+		t.Errorf("gomctest: Unable to save the counterexample to %v: %v", path, err)
+	} else {
+		desc = fmt.Sprintf("%v\nThe counterexample has been saved to %v and will be replayed the next time the test is run.", desc, path)
+	}
+	t.Errorf("gomctest: A property was violated.\n%v", formatCounterexample(desc, resp.Export()))
+	return false
+}
+
+// The path that the counterexample of the test is saved to.
+func ReplayPath(t testing.TB) string {
+	// Sub tests contain "/" in the name. Replace it so that all counterexamples are stored directly in the testdata directory
+	name := strings.NewReplacer("/", "_", "\\", "_", " ", "_").Replace(t.Name())
+	return filepath.Join(TestdataDir, name+".replay.json")
+}
+
+// Run the simulation, returning panics raised by the simulation as errors.
+//
+// Errors raised by the simulation are returned as they are, so that the cause of the panic can be inspected.
+func runSimulation[T, S any](sim gomc.Simulation[T, S], initNodes gomc.InitNodeOption[T], requests gomc.RequestOption, checker gomc.CheckerOption[S], opts ...gomc.RunOptions) (resp checking.CheckerResponse, err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			if e, ok := p.(error); ok {
+				err = e
+			} else {
+				err = fmt.Errorf("%v", p)
+			}
+		}
+	}()
+	return sim.Run(initNodes, requests, checker, opts...), nil
+}
+
+// Format the description of the response and the run that violated the property.
+func formatCounterexample(desc string, run []event.EventId) string {
+	return fmt.Sprintf("%v\nRun: %v", desc, run)
+}
+
+// Save the artifact to a file at the provided path. Creates the directory if it does not exist.
+func saveArtifact(path string, a replay.Artifact) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return a.Save(path)
+}
@@ -0,0 +1,243 @@
+package gomctest
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"gomc"
+	"gomc/checking"
+	"gomc/event"
+	"gomc/eventManager"
+	"gomc/replay"
+)
+
+type node struct {
+	id    int
+	send  func(int, string, ...any)
+	val   int
+	nodes []int
+}
+
+func (n *node) Set(val int) {
+	for _, id := range n.nodes {
+		n.send(id, "Update", val)
+	}
+}
+
+func (n *node) Update(val int) {
+	n.val = val
+}
+
+// Records failures instead of failing the test
+type recorder struct {
+	testing.TB
+	name   string
+	failed bool
+	errors []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Name() string { return r.name }
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.failed = true
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func (r *recorder) Logf(format string, args ...any) {}
+
+func check(t testing.TB, maxVal int, failingNodes ...int) bool {
+	return checkCrash(t, maxVal, func(*node) {}, failingNodes...)
+}
+
+func checkCrash(t testing.TB, maxVal int, crashFunc func(*node), failingNodes ...int) bool {
+	sim := gomc.PrepareSimulation(
+		gomc.WithTreeStateManager(
+			func(n *node) int { return n.val },
+			func(a, b int) bool { return a == b },
+		),
+	)
+	nodeIds := []int{0, 1}
+	requests := gomc.WithRequests(
+		gomc.NewRequest(0, "Set", 1),
+		gomc.NewRequest(1, "Set", 2),
+	)
+	predicate := func(s checking.State[int]) bool {
+		return checking.ForAllNodes(func(val int) bool { return val <= maxVal }, s, false)
+	}
+	return Check(t, sim,
+		gomc.ReplayConfig(nodeIds, failingNodes, requests, 0, predicate),
+		gomc.InitSingleNode(nodeIds, func(id int, sp eventManager.SimulationParameters) *node {
+			return &node{id: id, send: eventManager.NewSender(sp).SendFunc(id), nodes: nodeIds}
+		}),
+		requests,
+		gomc.WithPredicateChecker(predicate),
+		gomc.WithPerfectFailureManager(crashFunc, failingNodes...),
+	)
+}
+
+func TestCheck(t *testing.T) {
+	wd, _ := os.Getwd()
+	defer os.Chdir(wd)
+	os.Chdir(t.TempDir())
+
+	rec := &recorder{TB: t, name: "TestCounterexample"}
+	if check(rec, 1) {
+		t.Errorf("Expected the check to fail")
+	}
+	if !rec.failed {
+		t.Errorf("Expected the test to be failed")
+	}
+	path := ReplayPath(rec)
+	if _, err := os.Stat(path); err != nil {
+		t.Fatalf("Expected the counterexample to be saved. Got: %v", err)
+	}
+
+	// The saved counterexample is replayed and still fails
+	rec = &recorder{TB: t, name: "TestCounterexample"}
+	if check(rec, 1) {
+		t.Errorf("Expected the check to fail")
+	}
+	if len(rec.errors) != 1 {
+		t.Fatalf("Expected exactly one error from replaying the counterexample. Got: %v", rec.errors)
+	}
+
+	// The counterexample no longer fails. It is removed and the state space is explored
+	rec = &recorder{TB: t, name: "TestCounterexample"}
+	if !check(rec, 2) {
+		t.Errorf("Expected the check to pass. Got: %v", rec.errors)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("Expected the counterexample to be removed. Got: %v", err)
+	}
+}
+
+func TestCheckConfigMismatch(t *testing.T) {
+	wd, _ := os.Getwd()
+	defer os.Chdir(wd)
+	os.Chdir(t.TempDir())
+
+	// A counterexample recorded on other nodes
+	rec := &recorder{TB: t, name: "TestMismatch"}
+	path := ReplayPath(rec)
+	cfg := replay.NewConfig([]int{0, 1, 2}, nil, nil, 0, "")
+	if err := saveArtifact(path, replay.Artifact{Version: replay.Version, Config: cfg, Run: []event.EventId{"Function0"}, Events: []string{"Function0"}}); err != nil {
+		t.Fatalf("Unable to save the artifact: %v", err)
+	}
+
+	// The counterexample is removed without being replayed, and the state space is explored
+	if !check(rec, 2) {
+		t.Errorf("Expected the check to pass. Got: %v", rec.errors)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("Expected the counterexample to be removed. Got: %v", err)
+	}
+}
+
+// Replace the run of the saved counterexample
+func replaceRun(t *testing.T, path string, run ...event.EventId) {
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("Expected the counterexample to be saved. Got: %v", err)
+	}
+	artifact, err := replay.Read(f)
+	f.Close()
+	if err != nil {
+		t.Fatalf("Unable to read the counterexample: %v", err)
+	}
+	artifact.Run = run
+	artifact.Events = make([]string, len(run))
+	for i, id := range run {
+		artifact.Events[i] = string(id)
+	}
+	if err := saveArtifact(path, artifact); err != nil {
+		t.Fatalf("Unable to save the artifact: %v", err)
+	}
+}
+
+func TestCheckDivergence(t *testing.T) {
+	wd, _ := os.Getwd()
+	defer os.Chdir(wd)
+	os.Chdir(t.TempDir())
+
+	rec := &recorder{TB: t, name: "TestDivergence"}
+	check(rec, 1)
+	path := ReplayPath(rec)
+	replaceRun(t, path, "Unknown")
+
+	// The counterexample diverges. It is removed and the state space is explored
+	rec = &recorder{TB: t, name: "TestDivergence"}
+	if check(rec, 1) {
+		t.Errorf("Expected the check to fail")
+	}
+	if len(rec.errors) != 1 || !strings.Contains(rec.errors[0], "A property was violated") {
+		t.Errorf("Expected only the violation found by exploring the state space to be reported. Got: %v", rec.errors)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Errorf("Expected the new counterexample to be saved. Got: %v", err)
+	}
+}
+
+func TestCheckReplayError(t *testing.T) {
+	wd, _ := os.Getwd()
+	defer os.Chdir(wd)
+	os.Chdir(t.TempDir())
+
+	rec := &recorder{TB: t, name: "TestReplayError"}
+	check(rec, 1, 1)
+	path := ReplayPath(rec)
+	replaceRun(t, path, event.NewCrashEvent(1, nil).Id())
+
+	// Replaying the counterexample fails without diverging. The test fails and the counterexample is kept
+	rec = &recorder{TB: t, name: "TestReplayError"}
+	if checkCrash(rec, 1, func(*node) { panic("crash failed") }, 1) {
+		t.Errorf("Expected the check to fail")
+	}
+	if len(rec.errors) != 1 || !strings.Contains(rec.errors[0], "Unable to replay") {
+		t.Errorf("Expected the replay error to be reported. Got: %v", rec.errors)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Errorf("Expected the counterexample to be kept. Got: %v", err)
+	}
+}
+
+func TestCheckFailingNodesMismatch(t *testing.T) {
+	wd, _ := os.Getwd()
+	defer os.Chdir(wd)
+	os.Chdir(t.TempDir())
+
+	rec := &recorder{TB: t, name: "TestFailingNodes"}
+	if check(rec, 1) {
+		t.Errorf("Expected the check to fail")
+	}
+	path := ReplayPath(rec)
+
+	// The counterexample was recorded without failing nodes. It is removed, and the new counterexample is saved
+	rec = &recorder{TB: t, name: "TestFailingNodes"}
+	if check(rec, 1, 1) {
+		t.Errorf("Expected the check to fail")
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("Expected the counterexample to be saved. Got: %v", err)
+	}
+	defer f.Close()
+	artifact, err := replay.Read(f)
+	if err != nil {
+		t.Fatalf("Unable to read the counterexample: %v", err)
+	}
+	if len(artifact.Config.FailingNodes) != 1 || artifact.Config.FailingNodes[0] != 1 {
+		t.Errorf("Expected the counterexample to be recorded with failing node 1. Got: %v", artifact.Config.FailingNodes)
+	}
+}
+
+func TestReplayPath(t *testing.T) {
+	rec := &recorder{TB: t, name: "TestFoo/sub test"}
+	expected := "testdata/TestFoo_sub_test.replay.json"
+	if path := ReplayPath(rec); path != expected {
+		t.Errorf("Unexpected path. Got: %v. Expected: %v", path, expected)
+	}
+}
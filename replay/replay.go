@@ -0,0 +1,189 @@
+// Versioned replay artifacts.
+//
+// An artifact stores a run that violated a property together with the configuration of the simulation that produced it.
+// When an artifact is loaded the configuration is validated, so that a run is not replayed on a different scenario than the one it was recorded from.
+package replay
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"golang.org/x/exp/slices"
+
+	"gomc/checking"
+	"gomc/event"
+	"gomc/request"
+	"gomc/state"
+)
+
+// The version of the artifact format written by this package.
+//
+// Artifacts with a different version can not be loaded.
+const Version = 1
+
+// The configuration of the simulation that produced a run.
+//
+// Nodes is the ids of the nodes in the simulation.
+// FailingNodes is the ids of the nodes that crash at some point during the run.
+// Requests is a description of the requests sent to the nodes, in the order they were provided.
+// Seed is the seed used by the scheduler. It should be 0 if the scheduler is not randomized.
+// PropertiesHash identifies the set of properties that was checked. See checking.HashPredicates.
+type Config struct {
+	Nodes          []int    `json:"nodes"`
+	FailingNodes   []int    `json:"failingNodes"`
+	Requests       []string `json:"requests"`
+	Seed           int64    `json:"seed"`
+	PropertiesHash string   `json:"propertiesHash"`
+}
+
+// Create a Config.
+//
+// The requests are stored using their string representation.
+func NewConfig(nodes []int, failingNodes []int, requests []request.Request, seed int64, propertiesHash string) Config {
+	reqs := make([]string, len(requests))
+	for i, req := range requests {
+		reqs[i] = req.String()
+	}
+	return Config{
+		Nodes:          sorted(nodes),
+		FailingNodes:   sorted(failingNodes),
+		Requests:       reqs,
+		Seed:           seed,
+		PropertiesHash: propertiesHash,
+	}
+}
+
+// A replay artifact.
+//
+// Run is the sequence of event ids in the run, and Events is a description of each event in the run.
+type Artifact struct {
+	Version int             `json:"version"`
+	Config  Config          `json:"config"`
+	Run     []event.EventId `json:"run"`
+	Events  []string        `json:"events"`
+}
+
+// Create an artifact of the run that violated a property.
+//
+// Events are described using the event records of the response if the response exports them.
+// Otherwise the event ids are used as descriptions.
+func New(cfg Config, resp checking.CheckerResponse) Artifact {
+	run := resp.Export()
+	events := make([]string, len(run))
+	if r, ok := resp.(interface{ ExportRecords() []state.EventRecord }); ok && len(r.ExportRecords()) == len(run) {
+		for i, record := range r.ExportRecords() {
+			events[i] = record.Repr
+		}
+	} else {
+		for i, id := range run {
+			events[i] = string(id)
+		}
+	}
+	return Artifact{
+		Version: Version,
+		Config:  cfg,
+		Run:     run,
+		Events:  events,
+	}
+}
+
+// Validate that the artifact was recorded using the provided configuration.
+//
+// Returns a MismatchError listing the fields that differ, or nil if the configurations match.
+func (a Artifact) Validate(cfg Config) error {
+	mismatches := []string{}
+	if !slices.Equal(a.Config.Nodes, sorted(cfg.Nodes)) {
+		mismatches = append(mismatches, fmt.Sprintf("nodes: recorded %v, expected %v", a.Config.Nodes, sorted(cfg.Nodes)))
+	}
+	if !slices.Equal(a.Config.FailingNodes, sorted(cfg.FailingNodes)) {
+		mismatches = append(mismatches, fmt.Sprintf("failing nodes: recorded %v, expected %v", a.Config.FailingNodes, sorted(cfg.FailingNodes)))
+	}
+	if !slices.Equal(a.Config.Requests, cfg.Requests) {
+		mismatches = append(mismatches, fmt.Sprintf("requests: recorded %v, expected %v", a.Config.Requests, cfg.Requests))
+	}
+	if a.Config.Seed != cfg.Seed {
+		mismatches = append(mismatches, fmt.Sprintf("seed: recorded %v, expected %v", a.Config.Seed, cfg.Seed))
+	}
+	if a.Config.PropertiesHash != cfg.PropertiesHash {
+		mismatches = append(mismatches, fmt.Sprintf("properties: recorded %v, expected %v", a.Config.PropertiesHash, cfg.PropertiesHash))
+	}
+	if len(mismatches) > 0 {
+		return MismatchError{Mismatches: mismatches}
+	}
+	return nil
+}
+
+// Write the artifact to the writer as JSON
+func (a Artifact) Write(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(a)
+}
+
+// Save the artifact to a file at the provided path
+func (a Artifact) Save(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return a.Write(f)
+}
+
+// Read an artifact from the reader.
+//
+// Returns an error if the artifact has an unsupported version or is malformed.
+func Read(r io.Reader) (Artifact, error) {
+	var a Artifact
+	if err := json.NewDecoder(r).Decode(&a); err != nil {
+		return Artifact{}, fmt.Errorf("replay: Unable to decode the artifact: %w", err)
+	}
+	if a.Version != Version {
+		return Artifact{}, fmt.Errorf("replay: Unsupported artifact version %v. Expected version %v", a.Version, Version)
+	}
+	if len(a.Events) != len(a.Run) {
+		return Artifact{}, fmt.Errorf("replay: Malformed artifact. The run contains %v events, but %v events are described", len(a.Run), len(a.Events))
+	}
+	return a, nil
+}
+
+// Load the artifact stored at the provided path and validate that it was recorded using the provided configuration.
+//
+// Returns an error if the artifact can not be read or if the configuration does not match.
+func Load(path string, cfg Config) (Artifact, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return Artifact{}, err
+	}
+	defer f.Close()
+	a, err := Read(f)
+	if err != nil {
+		return Artifact{}, err
+	}
+	if err := a.Validate(cfg); err != nil {
+		return Artifact{}, err
+	}
+	return a, nil
+}
+
+// The configuration of the artifact does not match the configuration of the simulation.
+type MismatchError struct {
+	Mismatches []string
+}
+
+func (me MismatchError) Error() string {
+	return fmt.Sprintf("replay: The artifact was recorded using a different configuration:\n\t%v", strings.Join(me.Mismatches, "\n\t"))
+}
+
+// Returns a sorted copy of the slice
+func sorted(s []int) []int {
+	out := slices.Clone(s)
+	if out == nil {
+		out = []int{}
+	}
+	slices.Sort(out)
+	return out
+}
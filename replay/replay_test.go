@@ -0,0 +1,73 @@
+package replay
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"golang.org/x/exp/slices"
+
+	"gomc/event"
+	"gomc/request"
+)
+
+type mockResponse struct {
+	run []event.EventId
+}
+
+func (mr mockResponse) Response() (bool, string) { return false, "" }
+
+func (mr mockResponse) Export() []event.EventId { return mr.run }
+
+func testConfig() Config {
+	return NewConfig(
+		[]int{2, 0, 1},
+		[]int{1},
+		[]request.Request{{Id: 0, Method: "Propose"}},
+		0,
+		"hash",
+	)
+}
+
+func TestArtifactRoundTrip(t *testing.T) {
+	a := New(testConfig(), mockResponse{run: []event.EventId{"a", "b"}})
+
+	var buffer bytes.Buffer
+	if err := a.Write(&buffer); err != nil {
+		t.Fatalf("Unexpected error writing artifact: %v", err)
+	}
+	read, err := Read(&buffer)
+	if err != nil {
+		t.Fatalf("Unexpected error reading artifact: %v", err)
+	}
+	if !slices.Equal(read.Run, a.Run) || !slices.Equal(read.Events, []string{"a", "b"}) {
+		t.Errorf("Unexpected artifact. Got: %v. Expected: %v", read, a)
+	}
+	if err := read.Validate(testConfig()); err != nil {
+		t.Errorf("Expected the configuration to match. Got: %v", err)
+	}
+}
+
+func TestArtifactVersion(t *testing.T) {
+	_, err := Read(strings.NewReader(`{"version": 0, "run": [], "events": []}`))
+	if err == nil || !strings.Contains(err.Error(), "version") {
+		t.Errorf("Expected an error about the version. Got: %v", err)
+	}
+}
+
+func TestArtifactMismatch(t *testing.T) {
+	a := New(testConfig(), mockResponse{run: []event.EventId{"a"}})
+	cfg := testConfig()
+	cfg.FailingNodes = []int{2}
+	cfg.PropertiesHash = "other"
+
+	err := a.Validate(cfg)
+	var mismatch MismatchError
+	if !errors.As(err, &mismatch) {
+		t.Fatalf("Expected a MismatchError. Got: %v", err)
+	}
+	if len(mismatch.Mismatches) != 2 {
+		t.Errorf("Expected 2 mismatches. Got: %v", mismatch.Mismatches)
+	}
+}
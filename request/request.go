@@ -1,6 +1,10 @@
 package request
 
-import "reflect"
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
 
 // Represent a function call or request to a node
 // Id: The id of the node
@@ -11,3 +15,49 @@ type Request struct {
 	Method string
 	Params []reflect.Value
 }
+
+func (r Request) String() string {
+	params := make([]string, len(r.Params))
+	for i, param := range r.Params {
+		params[i] = fmt.Sprint(param)
+	}
+	return fmt.Sprintf("%v.%v(%v)", r.Id, r.Method, strings.Join(params, ", "))
+}
+
+// Validate that the request can be called on a node of type T.
+//
+// Checks that *T has an exported method with the name of the request,
+// and that the parameters of the request can be passed to the method, taking variadic methods into account.
+// Returns a descriptive error if the request can not be called on the node.
+func Validate[T any](r Request) error {
+	nodeType := reflect.TypeOf((*T)(nil))
+	method, ok := nodeType.MethodByName(r.Method)
+	if !ok {
+		return fmt.Errorf("Request: Invalid request %v. %v has no method %v", r, nodeType, r.Method)
+	}
+	// The first input of the method is the receiver
+	methodType := method.Type
+	numIn := methodType.NumIn() - 1
+	if methodType.IsVariadic() {
+		if len(r.Params) < numIn-1 {
+			return fmt.Errorf("Request: Invalid request %v. %v expects at least %v parameters. Got: %v", r, r.Method, numIn-1, len(r.Params))
+		}
+	} else if len(r.Params) != numIn {
+		return fmt.Errorf("Request: Invalid request %v. %v expects %v parameters. Got: %v", r, r.Method, numIn, len(r.Params))
+	}
+	for i, param := range r.Params {
+		var paramType reflect.Type
+		if methodType.IsVariadic() && i >= numIn-1 {
+			paramType = methodType.In(numIn).Elem()
+		} else {
+			paramType = methodType.In(i + 1)
+		}
+		if !param.IsValid() {
+			return fmt.Errorf("Request: Invalid request %v. Parameter %v of %v is nil. Expected a value of type %v", r, i, r.Method, paramType)
+		}
+		if !param.Type().AssignableTo(paramType) {
+			return fmt.Errorf("Request: Invalid request %v. Parameter %v of %v has type %v. Expected: %v", r, i, r.Method, param.Type(), paramType)
+		}
+	}
+	return nil
+}
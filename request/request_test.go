@@ -0,0 +1,55 @@
+package request
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type node struct{}
+
+func (n *node) NoParams()                        {}
+func (n *node) Propose(val string)               {}
+func (n *node) Send(to int, msgs ...[]byte)      {}
+func (n *node) Write(val interface{ Len() int }) {}
+func (n *node) unexported()                      {}
+
+func newRequest(method string, params ...any) Request {
+	valueParams := make([]reflect.Value, len(params))
+	for i, val := range params {
+		valueParams[i] = reflect.ValueOf(val)
+	}
+	return Request{Id: 0, Method: method, Params: valueParams}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name  string
+		req   Request
+		valid bool
+	}{
+		{"NoParams", newRequest("NoParams"), true},
+		{"Params", newRequest("Propose", "1"), true},
+		{"Misspelled", newRequest("Propse", "1"), false},
+		{"Unexported", newRequest("unexported"), false},
+		{"TooFewParams", newRequest("Propose"), false},
+		{"TooManyParams", newRequest("Propose", "1", "2"), false},
+		{"WrongType", newRequest("Propose", 1), false},
+		{"NilParam", newRequest("Propose", nil), false},
+		{"VariadicEmpty", newRequest("Send", 1), true},
+		{"Variadic", newRequest("Send", 1, []byte("a"), []byte("b")), true},
+		{"VariadicMissing", newRequest("Send"), false},
+		{"VariadicWrongType", newRequest("Send", 1, "a"), false},
+		{"Interface", newRequest("Write", []int{}), false},
+		{"Assignable", newRequest("Write", &strings.Builder{}), true},
+	}
+	for _, test := range tests {
+		err := Validate[node](test.req)
+		if test.valid && err != nil {
+			t.Errorf("%v: Unexpected error: %v", test.name, err)
+		}
+		if !test.valid && err == nil {
+			t.Errorf("%v: Expected an error validating %v", test.name, test.req)
+		}
+	}
+}
@@ -42,9 +42,10 @@ func NewRunner[T, S any](recordChanBuffer int) *Runner[T, S] {
 func (r *Runner[T, S]) Start(initNodes func(sp eventManager.SimulationParameters) map[int]*T, getState func(*T) S, stop func(*T), eventChanBuffer int) {
 
 	nodes := initNodes(eventManager.SimulationParameters{
-		CrashSubscribe: r.rc.CrashSubscribe,
+		CrashSubscribe: eventManager.CombineCrashSubscriptions(r.rc.CrashSubscribe),
 		EventAdder:     r.rc,
 		NextEvt:        r.rc.NextEvent,
+		Clock:          eventManager.RealClock{},
 	})
 	r.cmd = make(chan command)
 	r.rc.MainLoop(nodes, eventChanBuffer, stop, getState)
@@ -94,7 +95,11 @@ func (r *Runner[T, S]) Stop() error {
 //
 // Must be called after the running has been started.
 // Can be called from multiple goroutines.
+// Returns an error without sending the request if it can not be called on the node.
 func (r *Runner[T, S]) Request(req request.Request) error {
+	if err := request.Validate[T](req); err != nil {
+		return err
+	}
 	r.cmd <- requestCmd{
 		Id:     req.Id,
 		Method: req.Method,
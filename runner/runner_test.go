@@ -0,0 +1,30 @@
+package runner
+
+import (
+	"gomc/eventManager"
+	"gomc/request"
+	"reflect"
+	"testing"
+)
+
+func TestRunnerInvalidRequest(t *testing.T) {
+	r := NewRunner[MockNode, State](100)
+	r.Start(
+		func(sp eventManager.SimulationParameters) map[int]*MockNode {
+			return map[int]*MockNode{0: {Id: 0}}
+		},
+		GetState,
+		func(*MockNode) {},
+		eventBuffer,
+	)
+	defer r.Stop()
+
+	err := r.Request(request.Request{Id: 0, Method: "UpdateVal", Params: []reflect.Value{reflect.ValueOf("1")}})
+	if err == nil {
+		t.Errorf("Expected an error when sending a request with a parameter of the wrong type")
+	}
+	err = r.Request(request.Request{Id: 0, Method: "UpdateVal", Params: []reflect.Value{reflect.ValueOf(1)}})
+	if err != nil {
+		t.Errorf("Unexpected error sending a valid request: %v", err)
+	}
+}
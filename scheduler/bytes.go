@@ -0,0 +1,155 @@
+package scheduler
+
+import (
+	"gomc/event"
+	"sort"
+	"sync"
+)
+
+// A scheduler where the choice of the next event is decided by a slice of bytes.
+//
+// It is used to let a fuzzer, e.g. go test -fuzz, steer the exploration of the state space.
+// Each choice consumes one byte of the input. The byte is used to pick one of the pending events.
+// When the input is exhausted the pending event with the lowest id is always picked.
+// The same input will always result in the same run.
+//
+// The scheduler performs a single run, before stopping the simulation.
+type Bytes struct {
+	data []byte
+	done bool
+}
+
+// Create a new Bytes scheduler
+//
+// data is the input used to decide the choices of the run.
+func NewBytes(data []byte) *Bytes {
+	return &Bytes{
+		data: data,
+	}
+}
+
+// Create a RunScheduler that will communicate with the global scheduler
+//
+// The first run-specific scheduler will perform one run. The other will immediately return NoRunsErrors.
+func (b *Bytes) GetRunScheduler() RunScheduler {
+	if b.done {
+		return &bytesRun{done: true}
+	}
+	b.done = true
+	return newBytesRun(b.data)
+}
+
+// Reset the global state of the GlobalScheduler.
+// Prepare the scheduler for the next simulation.
+func (b *Bytes) Reset() {
+	b.done = false
+}
+
+// Manages the exploration of the state space in a single goroutine.
+// Events can safely be added from multiple goroutines.
+// Events will only be retrieved from a single goroutine during the simulation.
+// Communicates with the GlobalScheduler to ensure that the state exploration remains consistent.
+type bytesRun struct {
+	sync.Mutex
+
+	// The input deciding the choices of the run
+	data []byte
+	// The index of the next byte to be consumed
+	index int
+	// True when the run has been performed
+	done bool
+
+	pendingEvents []event.Event
+}
+
+// Create a new bytesRun scheduler
+func newBytesRun(data []byte) *bytesRun {
+	return &bytesRun{
+		data: data,
+
+		pendingEvents: make([]event.Event, 0),
+	}
+}
+
+// Get the next event in the run.
+//
+// Picks the next event using the next byte of the input.
+//
+// Will return RunEndedError if there are no more events in the run.
+// The event returned must be an event that has been added during the current run.
+// StartRun, EndRun and GetEvent will always be called from the same goroutine,
+// but not from the same goroutine as AddEvent.
+func (br *bytesRun) GetEvent() (event.Event, error) {
+	br.Lock()
+	defer br.Unlock()
+
+	if len(br.pendingEvents) == 0 {
+		return nil, RunEndedError
+	}
+
+	// Sort the pending events so that the choice does not depend on the order the events were added in.
+	// The sort is stable, so that events with the same id keep a deterministic order
+	sort.SliceStable(br.pendingEvents, func(i, j int) bool {
+		return br.pendingEvents[i].Id() < br.pendingEvents[j].Id()
+	})
+
+	index := 0
+	if br.index < len(br.data) {
+		index = int(br.data[br.index]) % len(br.pendingEvents)
+		br.index++
+	}
+	evt := br.pendingEvents[index]
+	br.pendingEvents = append(br.pendingEvents[:index], br.pendingEvents[index+1:]...)
+	return evt, nil
+}
+
+// Implements the event adder interface.
+//
+// It must be safe to add events from different goroutines.
+// StartRun, EndRun and GetEvent will always be called from the same goroutine,
+// but not from the same goroutine as AddEvent.
+func (br *bytesRun) AddEvent(evt event.Event) {
+	br.Lock()
+	defer br.Unlock()
+	br.pendingEvents = append(br.pendingEvents, evt)
+}
+
+// Implements the event remover interface.
+//
+// Removes the pending event with the provided id. Returns true if an event was removed.
+// It must be safe to remove events from different goroutines.
+func (br *bytesRun) RemoveEvent(id event.EventId) bool {
+	br.Lock()
+	defer br.Unlock()
+	var removed bool
+	br.pendingEvents, removed = removeEvent(br.pendingEvents, id)
+	return removed
+}
+
+// Prepare for starting a new run.
+//
+// Returns a NoRunsError if the run has been performed.
+// StartRun, EndRun and GetEvent will always be called from the same goroutine,
+// but not from the same goroutine as AddEvent.
+func (br *bytesRun) StartRun() error {
+	br.Lock()
+	defer br.Unlock()
+	if br.done {
+		return NoRunsError
+	}
+	br.index = 0
+	br.pendingEvents = make([]event.Event, 0)
+	return nil
+}
+
+// Finish the current run and prepare for the next one.
+//
+// Will always be called after a run has been completely executed,
+// even if an error occurred during execution of the run.
+// StartRun, EndRun and GetEvent will always be called from the same goroutine,
+// but not from the same goroutine as AddEvent.
+func (br *bytesRun) EndRun() {
+	br.Lock()
+	defer br.Unlock()
+	br.done = true
+}
@@ -0,0 +1,55 @@
+package scheduler
+
+import (
+	"errors"
+	"gomc/event"
+	"testing"
+
+	"golang.org/x/exp/slices"
+)
+
+func TestBytesScheduler(t *testing.T) {
+	tests := []struct {
+		data     []byte
+		expected []event.EventId
+	}{
+		{[]byte{}, []event.EventId{"0", "1", "2"}},
+		{[]byte{2, 1}, []event.EventId{"2", "1", "0"}},
+		{[]byte{4}, []event.EventId{"1", "0", "2"}},
+	}
+	for i, test := range tests {
+		gsch := NewBytes(test.data)
+		sch := gsch.GetRunScheduler()
+		if err := sch.StartRun(); err != nil {
+			t.Errorf("Received unexpected error in test %v: %v", i, err)
+		}
+		// The order the events are added in should not affect the run
+		sch.AddEvent(MockEvent{"2", 0, false})
+		sch.AddEvent(MockEvent{"0", 0, false})
+		sch.AddEvent(MockEvent{"1", 0, false})
+
+		run := []event.EventId{}
+		for {
+			evt, err := sch.GetEvent()
+			if errors.Is(err, RunEndedError) {
+				break
+			}
+			if err != nil {
+				t.Errorf("Received unexpected error in test %v: %v", i, err)
+				break
+			}
+			run = append(run, evt.Id())
+		}
+		if !slices.Equal(run, test.expected) {
+			t.Errorf("Received unexpected order of events in test %v. \n Got: %v\n Expected %v", i, run, test.expected)
+		}
+		sch.EndRun()
+
+		if err := sch.StartRun(); !errors.Is(err, NoRunsError) {
+			t.Errorf("Expected a NoRunsError after the run in test %v. Got: %v", i, err)
+		}
+		if err := gsch.GetRunScheduler().StartRun(); !errors.Is(err, NoRunsError) {
+			t.Errorf("Expected a NoRunsError from the second run scheduler in test %v. Got: %v", i, err)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package scheduler
+
+import (
+	"fmt"
+	"gomc/event"
+	"strings"
+)
+
+// A report of a replayed run diverging from the provided run.
+//
+// Returned by the Replay scheduler when the next event in the provided run is not pending.
+// Step is the index of the event in the provided run, starting at 1.
+// Expected is the id of the event that could not be found, and ExpectedDescription is a description of it if available.
+// Pending is the ids of the events that were pending when the run diverged.
+// Closest is the id of the pending event that most closely matches the expected event. It is empty if no events were pending.
+type DivergenceError struct {
+	Step                int
+	RunLength           int
+	Expected            event.EventId
+	ExpectedDescription string
+	Pending             []event.EventId
+	Closest             event.EventId
+}
+
+func (de DivergenceError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "RunScheduler: Unable to find next event. The run diverged at event %v of %v.\n", de.Step, de.RunLength)
+	if de.ExpectedDescription != "" {
+		fmt.Fprintf(&b, "Expected: %v (%v)\n", de.Expected, de.ExpectedDescription)
+	} else {
+		fmt.Fprintf(&b, "Expected: %v\n", de.Expected)
+	}
+	if de.Closest != "" {
+		fmt.Fprintf(&b, "Closest pending event: %v\n", de.Closest)
+	}
+	fmt.Fprintf(&b, "Pending events: %v", de.Pending)
+	return b.String()
+}
+
+// Find the event among the candidates whose id most closely matches the provided id.
+//
+// Ids are compared using their edit distance. If several events are equally close the first one is returned.
+// Returns -1 if there are no candidates.
+func closestEvent(id event.EventId, candidates []event.Event) int {
+	closest := -1
+	minDistance := 0
+	for i, evt := range candidates {
+		distance := editDistance(string(id), string(evt.Id()))
+		if closest == -1 || distance < minDistance {
+			closest = i
+			minDistance = distance
+		}
+	}
+	return closest
+}
+
+// Calculate the Levenshtein distance between the two strings
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = prev[j-1] + cost
+			if prev[j]+1 < curr[j] {
+				curr[j] = prev[j] + 1
+			}
+			if curr[j-1]+1 < curr[j] {
+				curr[j] = curr[j-1] + 1
+			}
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
@@ -2,6 +2,7 @@ package scheduler
 
 import (
 	"gomc/event"
+	"gomc/eventManager"
 	"sync"
 )
 
@@ -101,6 +102,22 @@ func (gs *runGuidedSearch) AddEvent(evt event.Event) {
 	}
 }
 
+// Implements the event remover interface.
+//
+// Removes the pending event with the provided id. Returns true if an event was removed. Returns false if the search scheduler does not support removing events.
+// It must be safe to remove events from different goroutines.
+func (gs *runGuidedSearch) RemoveEvent(id event.EventId) bool {
+	gs.Lock()
+	defer gs.Unlock()
+	if gs.useGuided {
+		return gs.guided.RemoveEvent(id)
+	}
+	if r, ok := gs.search.(eventManager.EventRemover); ok {
+		return r.RemoveEvent(id)
+	}
+	return false
+}
+
 // Prepare for starting a new run.
 //
 // Returns a NoRunsError if all possible runs have been completed.
@@ -111,7 +128,7 @@ func (gs *runGuidedSearch) StartRun() error {
 	gs.Lock()
 	defer gs.Unlock()
 	gs.useGuided = true
-	gs.guided = newRunReplay(gs.run)
+	gs.guided = newRunReplay(gs.run, nil)
 	err := gs.guided.StartRun()
 	if err != nil {
 		return err
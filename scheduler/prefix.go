@@ -189,6 +189,18 @@ func (rp *runPrefix) AddEvent(evt event.Event) {
 	rp.pendingEvents = append(rp.pendingEvents, evt)
 }
 
+// Implements the event remover interface.
+//
+// Removes the pending event with the provided id. Returns true if an event was removed.
+// It must be safe to remove events from different goroutines.
+func (rp *runPrefix) RemoveEvent(id event.EventId) bool {
+	rp.Lock()
+	defer rp.Unlock()
+	var removed bool
+	rp.pendingEvents, removed = removeEvent(rp.pendingEvents, id)
+	return removed
+}
+
 // Prepare for starting a new run.
 //
 // Returns a NoRunsError if all possible runs have been completed.
@@ -93,6 +93,18 @@ func (rs *randomRun) AddEvent(evt event.Event) {
 	rs.pendingEvents = append(rs.pendingEvents, evt)
 }
 
+// Implements the event remover interface.
+//
+// Removes the pending event with the provided id. Returns true if an event was removed.
+// It must be safe to remove events from different goroutines.
+func (rs *randomRun) RemoveEvent(id event.EventId) bool {
+	rs.Lock()
+	defer rs.Unlock()
+	var removed bool
+	rs.pendingEvents, removed = removeEvent(rs.pendingEvents, id)
+	return removed
+}
+
 // Prepare for starting a new run.
 //
 // Returns a NoRunsError if all possible runs have been completed.
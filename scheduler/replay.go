@@ -1,7 +1,6 @@
 package scheduler
 
 import (
-	"errors"
 	"gomc/event"
 	"sync"
 )
@@ -11,11 +10,19 @@ import (
 // The scheduler replays the run once, before stopping the simulation.
 // It does not further explore the state space.
 //
-// If the algorithm has been changed, and the scheduler is unable to follow the provided run it will return errors.
+// If the algorithm has been changed, and the scheduler is unable to follow the provided run it will return a DivergenceError.
+// In best effort mode it will instead continue with the pending event that most closely matches the expected event.
 // It will stop after replaying the provided run, even if there are more pending events.
 type Replay struct {
-	run  []event.EventId
-	done bool
+	sync.Mutex
+	run          []event.EventId
+	descriptions []string
+	bestEffort   bool
+	done         bool
+
+	divergences []DivergenceError
+	// Called with each divergence in best effort mode. May be nil
+	onDivergence func(DivergenceError)
 }
 
 // Create a new Replay scheduler
@@ -27,21 +34,73 @@ func NewReplay(run []event.EventId) *Replay {
 	}
 }
 
+// Create a new Replay scheduler with descriptions of the events in the run
+//
+// run is the sequence of events that will be replayed.
+// descriptions is a description of each event in the run.
+// The descriptions are used to provide a more detailed error if the scheduler is unable to follow the run.
+func NewReplayWithDescriptions(run []event.EventId, descriptions []string) *Replay {
+	return &Replay{
+		run:          run,
+		descriptions: descriptions,
+	}
+}
+
+// Create a new Replay scheduler in best effort mode
+//
+// run is the sequence of events that will be replayed.
+// descriptions is a description of each event in the run. May be nil.
+// When the next event in the run is not pending the scheduler continues with the pending event that most closely matches it.
+// onDivergence is called with each divergence when it is encountered. May be nil.
+// The divergences can also be retrieved using Divergences.
+func NewBestEffortReplay(run []event.EventId, descriptions []string, onDivergence func(DivergenceError)) *Replay {
+	return &Replay{
+		run:          run,
+		descriptions: descriptions,
+		bestEffort:   true,
+		onDivergence: onDivergence,
+	}
+}
+
+// Returns the divergences from the provided run that was encountered in best effort mode.
+func (r *Replay) Divergences() []DivergenceError {
+	r.Lock()
+	defer r.Unlock()
+	return append([]DivergenceError{}, r.divergences...)
+}
+
+// Record a divergence encountered by a run scheduler
+func (r *Replay) addDivergence(de DivergenceError) {
+	r.Lock()
+	r.divergences = append(r.divergences, de)
+	r.Unlock()
+	if r.onDivergence != nil {
+		r.onDivergence(de)
+	}
+}
+
 // Create a RunScheduler that will communicate with the global scheduler
 //
 // The first run-specific scheduler will replay the run once. The other will immediately return NoRunsErrors.
 func (r *Replay) GetRunScheduler() RunScheduler {
 	if r.done {
-		return newRunReplay(nil)
+		return newRunReplay(nil, nil)
 	}
 	r.done = true
-	return newRunReplay(r.run)
+	rr := newRunReplay(r.run, r.descriptions)
+	if r.bestEffort {
+		rr.onDivergence = r.addDivergence
+	}
+	return rr
 }
 
 // Reset the global state of the GlobalScheduler.
 // Prepare the scheduler for the next simulation.
 func (r *Replay) Reset() {
+	r.Lock()
+	defer r.Unlock()
 	r.done = false
+	r.divergences = nil
 }
 
 // Manages the exploration of the state space in a single goroutine.
@@ -52,17 +111,22 @@ type runReplay struct {
 	sync.Mutex
 	// A slice of the run to be replayed with event ids in order
 	run []event.EventId
+	// Descriptions of the events in the run. May be nil
+	descriptions []string
 	// The index of the current event
 	index int
+	// Called with the divergence when running in best effort mode. nil otherwise
+	onDivergence func(DivergenceError)
 
 	pendingEvents []event.Event
 }
 
 // Create a new runReplay scheduler
-func newRunReplay(run []event.EventId) *runReplay {
+func newRunReplay(run []event.EventId, descriptions []string) *runReplay {
 	return &runReplay{
-		index: 0,
-		run:   run,
+		index:        0,
+		run:          run,
+		descriptions: descriptions,
 
 		pendingEvents: make([]event.Event, 0),
 	}
@@ -71,7 +135,8 @@ func newRunReplay(run []event.EventId) *runReplay {
 // Get the next event in the run.
 //
 // Gets the next event in the provided run.
-// Returns an error if it is unable to find the event.
+// Returns a DivergenceError if it is unable to find the event.
+// In best effort mode it instead returns the pending event that most closely matches the event.
 //
 // Will return RunEndedError if there are no more events in the run.
 // The event returned must be an event that has been added during the current run.
@@ -87,7 +152,12 @@ func (rr *runReplay) GetEvent() (event.Event, error) {
 	evtId := rr.run[rr.index]
 	evt := rr.popEvent(evtId)
 	if evt == nil {
-		return nil, errors.New("RunScheduler: Unable to find next event")
+		de := rr.divergence(evtId)
+		if rr.onDivergence == nil || de.Closest == "" {
+			return nil, de
+		}
+		rr.onDivergence(de)
+		evt = rr.popEvent(de.Closest)
 	}
 	rr.index++
 	return evt, nil
@@ -106,6 +176,26 @@ func (rr *runReplay) popEvent(id event.EventId) event.Event {
 	return nil
 }
 
+// Create a report of where the run diverged from the provided run.
+func (rr *runReplay) divergence(id event.EventId) DivergenceError {
+	de := DivergenceError{
+		Step:      rr.index + 1,
+		RunLength: len(rr.run),
+		Expected:  id,
+		Pending:   make([]event.EventId, len(rr.pendingEvents)),
+	}
+	if rr.index < len(rr.descriptions) {
+		de.ExpectedDescription = rr.descriptions[rr.index]
+	}
+	for i, evt := range rr.pendingEvents {
+		de.Pending[i] = evt.Id()
+	}
+	if closest := closestEvent(id, rr.pendingEvents); closest >= 0 {
+		de.Closest = rr.pendingEvents[closest].Id()
+	}
+	return de
+}
+
 // Add an event to the list of possible events
 //
 // It must be safe to add events from different goroutines.
@@ -117,6 +207,18 @@ func (rr *runReplay) AddEvent(evt event.Event) {
 	rr.pendingEvents = append(rr.pendingEvents, evt)
 }
 
+// Implements the event remover interface.
+//
+// Removes the pending event with the provided id. Returns true if an event was removed.
+// It must be safe to remove events from different goroutines.
+func (rr *runReplay) RemoveEvent(id event.EventId) bool {
+	rr.Lock()
+	defer rr.Unlock()
+	var removed bool
+	rr.pendingEvents, removed = removeEvent(rr.pendingEvents, id)
+	return removed
+}
+
 	// Prepare for starting a new run.
 	//
 	// Returns a NoRunsError if all possible runs have been completed.
@@ -3,6 +3,7 @@ package scheduler
 import (
 	"errors"
 	"gomc/event"
+	"strings"
 	"testing"
 
 	"golang.org/x/exp/slices"
@@ -153,3 +154,80 @@ var replaySchedulerTest = []struct {
 		expectedErr: false,
 	},
 }
+
+func TestReplaySchedulerDiverged(t *testing.T) {
+	gsch := NewReplayWithDescriptions([]event.EventId{"1", "3"}, []string{"First", "Third"})
+	sch := gsch.GetRunScheduler()
+	if err := sch.StartRun(); err != nil {
+		t.Errorf("Received unexpected error: %v", err)
+	}
+	sch.AddEvent(MockEvent{id: "1"})
+	sch.AddEvent(MockEvent{id: "2"})
+	if _, err := sch.GetEvent(); err != nil {
+		t.Errorf("Received unexpected error: %v", err)
+	}
+	_, err := sch.GetEvent()
+	if err == nil {
+		t.Fatalf("Expected an error when the run diverged")
+	}
+	var de DivergenceError
+	if !errors.As(err, &de) || de.Closest != "2" {
+		t.Errorf("Expected a DivergenceError with 2 as the closest event. Got: %#v", err)
+	}
+	for _, expected := range []string{"event 2 of 2", "3 (Third)", "[2]"} {
+		if !strings.Contains(err.Error(), expected) {
+			t.Errorf("Expected the error to contain %q. Got: %v", expected, err)
+		}
+	}
+}
+
+func TestReplaySchedulerBestEffort(t *testing.T) {
+	reported := []DivergenceError{}
+	gsch := NewBestEffortReplay([]event.EventId{"1", "Msg3", "2"}, nil, func(de DivergenceError) { reported = append(reported, de) })
+	sch := gsch.GetRunScheduler()
+	if err := sch.StartRun(); err != nil {
+		t.Errorf("Received unexpected error: %v", err)
+	}
+	for _, evt := range []MockEvent{{id: "1"}, {id: "2"}, {id: "Msg4"}} {
+		sch.AddEvent(evt)
+	}
+	actualRun := []event.EventId{}
+	for {
+		evt, err := sch.GetEvent()
+		if errors.Is(err, RunEndedError) {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Received unexpected error: %v", err)
+		}
+		actualRun = append(actualRun, evt.Id())
+	}
+	expected := []event.EventId{"1", "Msg4", "2"}
+	if !slices.Equal(actualRun, expected) {
+		t.Errorf("Received unexpected run. \nGot: %v. \nExpected: %v", actualRun, expected)
+	}
+	divergences := gsch.Divergences()
+	if len(divergences) != 1 || divergences[0].Step != 2 || divergences[0].Closest != "Msg4" {
+		t.Errorf("Unexpected divergences: %v", divergences)
+	}
+	if len(reported) != 1 || reported[0].Step != 2 {
+		t.Errorf("Expected the divergence to be reported. Got: %v", reported)
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		distance int
+	}{
+		{"", "", 0},
+		{"abc", "", 3},
+		{"kitten", "sitting", 3},
+		{"Msg3", "Msg4", 1},
+	}
+	for _, test := range tests {
+		if d := editDistance(test.a, test.b); d != test.distance {
+			t.Errorf("Unexpected distance between %q and %q. Got: %v. Expected: %v", test.a, test.b, d, test.distance)
+		}
+	}
+}
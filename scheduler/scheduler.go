@@ -67,3 +67,15 @@ var (
 	// The simulation will stop.
 	NoRunsError = errors.New("scheduler: No available new runs to be started.")
 )
+
+// Remove the first event with the provided id from the pending events.
+//
+// Returns the remaining events and true if an event was removed.
+func removeEvent(pending []event.Event, id event.EventId) ([]event.Event, bool) {
+	for i, evt := range pending {
+		if evt.Id() == id {
+			return append(pending[:i], pending[i+1:]...), true
+		}
+	}
+	return pending, false
+}
@@ -3,6 +3,7 @@ package scheduler
 import (
 	"errors"
 	"gomc/event"
+	"gomc/eventManager"
 	"strconv"
 	"testing"
 )
@@ -242,3 +243,40 @@ func (me MockEvent) Execute(_ any, chn chan error) {
 func (me MockEvent) Target() int {
 	return me.target
 }
+
+func TestRemoveEvent(t *testing.T) {
+	schedulers := map[string]GlobalScheduler{
+		"Prefix":       NewPrefix(),
+		"Random":       NewRandom(1),
+		"Bytes":        NewBytes(nil),
+		"Replay":       NewReplay([]event.EventId{"1"}),
+		"GuidedSearch": NewGuidedSearch(NewPrefix(), []event.EventId{"1"}),
+	}
+	for name, gsch := range schedulers {
+		sch := gsch.GetRunScheduler()
+		if err := sch.StartRun(); err != nil {
+			t.Fatalf("%v: Did not expect to receive an error. Got %v", name, err)
+		}
+		sch.AddEvent(MockEvent{"0", 0, false})
+		sch.AddEvent(MockEvent{"1", 0, false})
+
+		remover, ok := sch.(eventManager.EventRemover)
+		if !ok {
+			t.Fatalf("%v: Expected the scheduler to support removing events", name)
+		}
+		if !remover.RemoveEvent("0") {
+			t.Errorf("%v: Expected the pending event to be removed", name)
+		}
+		if remover.RemoveEvent("0") {
+			t.Errorf("%v: Did not expect an event that is not pending to be removed", name)
+		}
+
+		evt, err := sch.GetEvent()
+		if err != nil || evt.Id() != "1" {
+			t.Errorf("%v: Expected to receive the remaining event. Got: %v, %v", name, evt, err)
+		}
+		if _, err := sch.GetEvent(); !errors.Is(err, RunEndedError) {
+			t.Errorf("%v: Expected the run to end. Got: %v", name, err)
+		}
+	}
+}
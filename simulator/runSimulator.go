@@ -8,8 +8,10 @@ import (
 	"gomc/failureManager"
 	"gomc/request"
 	"gomc/scheduler"
+	"gomc/state"
 	"gomc/stateManager"
 	"runtime/debug"
+	"strings"
 )
 
 // Performs the simulation of runs
@@ -67,7 +69,7 @@ func (rs *runSimulator[T, S]) SimulateRuns(nextRun chan bool, status chan error,
 // Simulating consists of three parts: initialization, execution and teardown of the run.
 // teardown of the run is always called after the run, even if errors occur.
 func (rs *runSimulator[T, S]) simulateRun(cfg *runParameters[T]) error {
-	nodes, err := rs.initRun(cfg.initNodes, cfg.requests...)
+	nodes, err := rs.initRun(cfg.initNodes, cfg.networkFaults, cfg.requests...)
 	if err != nil {
 		return fmt.Errorf("Simulator: An error occurred while initializing a run: %w", err)
 	}
@@ -77,7 +79,7 @@ func (rs *runSimulator[T, S]) simulateRun(cfg *runParameters[T]) error {
 
 	err = rs.executeRun(nodes)
 	if err != nil {
-		return fmt.Errorf("Simulator: An error occurred while simulating a run: %v", err)
+		return fmt.Errorf("Simulator: An error occurred while simulating a run: %w", err)
 	}
 	return nil
 }
@@ -87,14 +89,29 @@ func (rs *runSimulator[T, S]) simulateRun(cfg *runParameters[T]) error {
 // Creates the nodes and collects the initial state.
 // prepares the scheduler and the failure manager for the new run.
 // schedules new requests.
-func (rs *runSimulator[T, S]) initRun(initNodes func(sp eventManager.SimulationParameters) map[int]*T, requests ...request.Request) (map[int]*T, error) {
-	nodes := initNodes(eventManager.SimulationParameters{
+// If networkFaults is not nil a new model of network faults is created for the run.
+func (rs *runSimulator[T, S]) initRun(initNodes func(sp eventManager.SimulationParameters) map[int]*T, networkFaults func() *eventManager.NetworkFaults, requests ...request.Request) (map[int]*T, error) {
+	sp := eventManager.SimulationParameters{
 		NextEvt:        rs.nextEvent,
-		CrashSubscribe: rs.fm.Subscribe,
+		CrashSubscribe: eventManager.CombineCrashSubscriptions(rs.fm.Subscribe),
 		EventAdder:     rs.sch,
-	})
+		Clock:          eventManager.NewSimulatedClock(),
+	}
+	if networkFaults != nil {
+		sp.NetworkFaults = networkFaults()
+	}
+	if lo, ok := rs.fm.(failureManager.LeaderOracle); ok {
+		sp.LeaderSubscribe = lo.LeaderSubscribe
+	}
+	if mi, ok := rs.fm.(eventManager.MessageInterceptor); ok {
+		sp.MessageInterceptor = mi
+	}
+	if r, ok := rs.fm.(failureManager.SimulationParametersReceiver); ok {
+		r.SetSimulationParameters(sp)
+	}
+	nodes := initNodes(sp)
 
-	rs.sm.UpdateGlobalState(nodes, rs.fm.CorrectNodes(), nil)
+	rs.sm.UpdateGlobalState(nodes, rs.fm.CorrectNodes(), rs.partition(), nil, false)
 
 	err := rs.sch.StartRun()
 	if err != nil {
@@ -141,6 +158,8 @@ func (rs *runSimulator[T, S]) executeRun(nodes map[int]*T) error {
 		evt, err := rs.sch.GetEvent()
 		if errors.Is(err, scheduler.RunEndedError) {
 			return nil
+		} else if errors.As(err, &scheduler.DivergenceError{}) {
+			return fmt.Errorf("%w\nStates leading up to the divergence:\n%v", err, formatStates(rs.sm.Run()))
 		} else if err != nil {
 			return err
 		}
@@ -148,11 +167,18 @@ func (rs *runSimulator[T, S]) executeRun(nodes map[int]*T) error {
 		if !ok {
 			return fmt.Errorf("Event not targeting an existing node. Targeting %v", evt.Target())
 		}
-		err = rs.executeEvent(node, evt)
-		if err != nil {
-			return err
+		dropped := false
+		if msg, ok := evt.(event.MessageEvent); ok && rs.partitioned(msg) {
+			// The message is dropped by the network. The message is recorded as dropped, so that the run can be replayed
+			rs.dropMessage(msg)
+			dropped = true
+		} else {
+			err = rs.executeEvent(node, evt)
+			if err != nil {
+				return err
+			}
 		}
-		rs.sm.UpdateGlobalState(nodes, rs.fm.CorrectNodes(), evt)
+		rs.sm.UpdateGlobalState(nodes, rs.fm.CorrectNodes(), rs.partition(), evt, dropped)
 		depth++
 	}
 	return nil
@@ -179,6 +205,34 @@ func (rs *runSimulator[T, S]) executeEvent(node *T, evt event.Event) error {
 	return <-rs.nextEvt
 }
 
+// Returns the active partition of the network if the failure manager partitions the network.
+//
+// Returns nil if the network is not partitioned.
+func (rs *runSimulator[T, S]) partition() map[int]int {
+	if p, ok := rs.fm.(failureManager.Partitioner); ok {
+		return p.Partition()
+	}
+	return nil
+}
+
+// Returns true if the sender and the receiver of the message are in different groups of the active partition
+func (rs *runSimulator[T, S]) partitioned(msg event.MessageEvent) bool {
+	partition := rs.partition()
+	if partition == nil {
+		return false
+	}
+	return partition[msg.From()] != partition[msg.To()]
+}
+
+// Drop the message without delivering it.
+//
+// Releases the resources held by the message if it is a DroppableEvent.
+func (rs *runSimulator[T, S]) dropMessage(msg event.MessageEvent) {
+	if d, ok := msg.(event.DroppableEvent); ok {
+		d.Drop()
+	}
+}
+
 // Add the requests to the scheduler.
 //
 // Discards the request if the target node of the request is not a valid node
@@ -210,7 +264,17 @@ func (rs *runSimulator[T, S]) nextEvent(status error, _ int) {
 // Stores the parameters used to start a run.
 // Should be read only.
 type runParameters[T any] struct {
-	initNodes func(sp eventManager.SimulationParameters) map[int]*T
-	stopNode  func(*T)
-	requests  []request.Request
+	initNodes     func(sp eventManager.SimulationParameters) map[int]*T
+	stopNode      func(*T)
+	requests      []request.Request
+	networkFaults func() *eventManager.NetworkFaults
+}
+
+// Format the states of a run with one state on each line
+func formatStates[S any](states []state.GlobalState[S]) string {
+	var b strings.Builder
+	for _, s := range states {
+		fmt.Fprintf(&b, "-> %v\n", s)
+	}
+	return b.String()
 }
@@ -17,6 +17,9 @@ type Simulator[T any, S any] struct {
 	// The scheduler keeps track of the events and selects the next event to be executed
 	Scheduler scheduler.GlobalScheduler
 
+	// Creates the model of network faults used in each run. If nil the network is reliable
+	NetworkFaults func() *eventManager.NetworkFaults
+
 	sm stateManager.StateManager[T, S]
 
 	// If true will ignore all errors while simulating runs. Will return aggregate of errors at the end. If false will interrupt simulation if an error occur
@@ -68,18 +71,25 @@ func NewSimulator[T any, S any](sch scheduler.GlobalScheduler, sm stateManager.S
 //
 // requests is a variadic arguments of functions that will be scheduled as events by the scheduler. These are used to start the execution of the argument and can represent commands or requests to the service.
 // At least one function must be provided for the simulation to start. Otherwise the simulator returns an error.
+// The simulator also returns an error if any of the requests can not be called on the nodes.
 //
 // Simulate returns nil if the it runs to completion or reaches the max number of runs. It returns an error if it was unable to complete the simulation.
 func (s Simulator[T, S]) Simulate(fm failureManager.FailureManger[T], initNodes func(eventManager.SimulationParameters) map[int]*T, stopFunc func(*T), requests ...request.Request) error {
 	if len(requests) < 1 {
 		return fmt.Errorf("Simulator: At least one request should be provided to start simulation.")
 	}
+	for _, req := range requests {
+		if err := request.Validate[T](req); err != nil {
+			return fmt.Errorf("Simulator: %w", err)
+		}
+	}
 
 	// Pack the parameters into a runParameter to make it easier to handle
 	cfg := &runParameters[T]{
-		initNodes: initNodes,
-		stopNode:  stopFunc,
-		requests:  requests,
+		initNodes:     initNodes,
+		stopNode:      stopFunc,
+		requests:      requests,
+		networkFaults: s.NetworkFaults,
 	}
 
 	// Reset the state of modules so that they are ready for a new simulation
@@ -42,6 +42,24 @@ func TestSimulatorNoEvents(t *testing.T) {
 	}
 }
 
+func TestSimulatorInvalidRequest(t *testing.T) {
+	sch := NewMockGlobalScheduler()
+	sm := NewMockStateManager()
+	fm := NewMockFailureManager([]int{}, func(*MockNode) {})
+	simulator := NewSimulator[MockNode, State](sch, sm, false, false, 10000, 1000, 1)
+	err := simulator.Simulate(
+		fm,
+		func(sp eventManager.SimulationParameters) map[int]*MockNode {
+			return map[int]*MockNode{0: {}}
+		},
+		func(t *MockNode) {},
+		request.Request{Id: 0, Method: "UpdateValue", Params: []reflect.Value{reflect.ValueOf(1)}},
+	)
+	if err == nil {
+		t.Errorf("Expected to receive an error when requesting a method that does not exist")
+	}
+}
+
 func TestAddRequests(t *testing.T) {
 	sch := NewMockRunScheduler()
 	gsm := NewMockStateManager()
@@ -325,7 +343,7 @@ func TestInitRun(t *testing.T) {
 
 		sch.runEnded = test.runEnded
 
-		nodes, err := sim.initRun(test.initNodes, test.requests...)
+		nodes, err := sim.initRun(test.initNodes, nil, test.requests...)
 		isErr := (err != nil)
 		if isErr != test.expectedError {
 			if isErr {
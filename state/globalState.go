@@ -18,8 +18,18 @@ type GlobalState[S any] struct {
 	// All nodes are represented in the map.
 	Correct map[int]bool
 
+	// A map storing the group of each node in the active partition of the network.
+	//
+	// The map stores (id, group) combination.
+	// Nodes can only communicate with nodes in the same group.
+	// Is nil if the network is not partitioned.
+	Partition map[int]int
+
 	// A record of the event that caused the transition into this state
 	Evt EventRecord
+
+	// True if the event was a message that was dropped by the partitioned network instead of being delivered.
+	Dropped bool
 }
 
 func (gs GlobalState[S]) String() string {
@@ -29,5 +39,11 @@ func (gs GlobalState[S]) String() string {
 			crashed = append(crashed, id)
 		}
 	}
+	if gs.Dropped {
+		return fmt.Sprintf("Evt: %v (Dropped)\t States: %v\t Crashed: %v\t Partition: %v\t", gs.Evt, gs.LocalStates, crashed, gs.Partition)
+	}
+	if gs.Partition != nil {
+		return fmt.Sprintf("Evt: %v\t States: %v\t Crashed: %v\t Partition: %v\t", gs.Evt, gs.LocalStates, crashed, gs.Partition)
+	}
 	return fmt.Sprintf("Evt: %v\t States: %v\t Crashed: %v\t", gs.Evt, gs.LocalStates, crashed)
 }
@@ -37,8 +37,10 @@ func NewRunStateManager[T, S any](sm StateManager[T, S], getLocalState func(*T)
 // Aggregate the local states, the status, and the event into the GlobalState and add it to the run. 
 // nodes is the map of nodes used in this run.
 // correct is a map of the status of the nodes.
+// partition is a map of the group of each node in the active partition of the network, or nil if the network is not partitioned.
 // evt is the event that caused the transition into the current state.
-func (rss *RunStateManager[T, S]) UpdateGlobalState(nodes map[int]*T, correct map[int]bool, evt event.Event) {
+// dropped is true if evt is a message that was dropped by the network instead of being delivered.
+func (rss *RunStateManager[T, S]) UpdateGlobalState(nodes map[int]*T, correct map[int]bool, partition map[int]int, evt event.Event, dropped bool) {
 	states := map[int]S{}
 	for id, node := range nodes {
 		states[id] = rss.getLocalState(node)
@@ -47,10 +49,17 @@ func (rss *RunStateManager[T, S]) UpdateGlobalState(nodes map[int]*T, correct ma
 	rss.run = append(rss.run, state.GlobalState[S]{
 		LocalStates: states,
 		Correct:     maps.Clone(correct),
+		Partition:   maps.Clone(partition),
 		Evt:         state.CreateEventRecord(evt),
+		Dropped:     dropped,
 	})
 }
 
+// Returns the states collected in the current run so far
+func (rss *RunStateManager[T, S]) Run() []state.GlobalState[S] {
+	return rss.run
+}
+
 func (rss *RunStateManager[T, S]) EndRun() {
 	rss.sm.AddRun(rss.run)
 	rss.run = make([]state.GlobalState[S], 0)
@@ -24,7 +24,7 @@ func TestStateMangerMerge(t *testing.T) {
 					rst := sm.GetRunStateManager()
 					for _, k := range runLength {
 						nodes, correct, evt := generateMockData(numNodes, k)
-						rst.UpdateGlobalState(nodes, correct, evt)
+						rst.UpdateGlobalState(nodes, correct, nil, evt, false)
 					}
 					rst.EndRun()
 					wait.Done()
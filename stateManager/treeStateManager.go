@@ -65,13 +65,13 @@ func (sm *TreeStateManager[T, S]) AddRun(run []state.GlobalState[S]) {
 // Initializes the state tree with the provided state as the initial state
 func (sm *TreeStateManager[T, S]) initStateTree(s state.GlobalState[S]) *tree.Tree[state.GlobalState[S]] {
 	cmp := func(a, b state.GlobalState[S]) bool {
-		if a.Evt.Id != b.Evt.Id {
+		if a.Evt.Id != b.Evt.Id || a.Dropped != b.Dropped {
 			return false
 		}
 		if !maps.EqualFunc(a.LocalStates, b.LocalStates, sm.stateEq) {
 			return false
 		}
-		return maps.Equal(a.Correct, b.Correct)
+		return maps.Equal(a.Correct, b.Correct) && maps.Equal(a.Partition, b.Partition)
 	}
 	stateRoot := tree.New(s, cmp)
 	return stateRoot
@@ -0,0 +1,310 @@
+package gomc
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"sync"
+
+	"gomc/checking"
+	"gomc/config"
+	"gomc/request"
+)
+
+// A scenario that is simulated as part of a sweep.
+//
+// Nodes is the ids of the nodes used in the scenario.
+// CrashedNodes is the ids of the nodes that crash at some point during the scenario.
+// Requests is the requests sent to the nodes in the order that they are provided to the simulation.
+type Scenario struct {
+	Nodes        []int
+	CrashedNodes []int
+	Requests     []request.Request
+}
+
+func (s Scenario) String() string {
+	return fmt.Sprintf("{Nodes: %v, Crashed: %v, Requests: %v}", s.Nodes, s.CrashedNodes, s.Requests)
+}
+
+// The result of simulating a single scenario.
+//
+// Err is set if the simulation of the scenario could not be completed.
+// Otherwise, Response stores the response returned by the checker.
+type ScenarioResult struct {
+	Scenario Scenario
+	Response checking.CheckerResponse
+	Err      error
+}
+
+// Returns true if all properties held for the scenario and it was simulated without errors.
+func (sr ScenarioResult) Ok() bool {
+	if sr.Err != nil {
+		return false
+	}
+	ok, _ := sr.Response.Response()
+	return ok
+}
+
+// A consolidated report of a sweep.
+//
+// Scenarios is the total number of scenarios that was simulated.
+// Failed contains the results of the scenarios that violated some property or that could not be simulated.
+type SweepReport struct {
+	Scenarios int
+	Failed    []ScenarioResult
+}
+
+// Create a response.
+//
+// Returns a boolean that is true if all scenarios passed, false otherwise.
+// Returns a string naming the scenarios that failed and describing the failures.
+func (sr SweepReport) Response() (bool, string) {
+	if len(sr.Failed) == 0 {
+		return true, fmt.Sprintf("All %v scenarios passed", sr.Scenarios)
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "%v of %v scenarios failed:\n", len(sr.Failed), sr.Scenarios)
+	for _, res := range sr.Failed {
+		fmt.Fprintf(&b, "Scenario %v:\n", res.Scenario)
+		if res.Err != nil {
+			fmt.Fprintf(&b, "Error: %v\n", res.Err)
+			continue
+		}
+		_, desc := res.Response.Response()
+		fmt.Fprintf(&b, "%v\n", desc)
+	}
+	return false, b.String()
+}
+
+// Simulate the cross product of node sets, failing nodes and requests.
+//
+// prepare is used to create the Simulation used to simulate the scenarios.
+// It is called once for each concurrently simulated scenario, since a Simulation can only run one simulation at a time.
+// initNodes creates the InitNodeOption used for the provided node set.
+// requests creates the RequestOption used for the provided node set.
+// checker is used to verify all scenarios.
+//
+// See the SweepOptions for a full overview of the possible options.
+// At least one node set must be provided.
+//
+// Returns a SweepReport naming the scenarios that failed.
+func Sweep[T, S any](prepare func() Simulation[T, S], initNodes func(nodes []int) InitNodeOption[T], requests func(nodes []int) RequestOption, checker CheckerOption[S], opts ...SweepOption) SweepReport {
+	var (
+		nodeSets = [][]int{}
+
+		crashFunc  = func(*T) {}
+		maxCrashed = 0
+
+		permuteRequests = false
+
+		numConcurrent = runtime.GOMAXPROCS(0)
+
+		runOpts = []RunOptions{}
+	)
+
+	for _, opt := range opts {
+		switch t := opt.(type) {
+		case config.SweepNodesOption:
+			nodeSets = append(nodeSets, t.Nodes...)
+		case config.SweepCrashOption[T]:
+			crashFunc = t.CrashFunc
+			maxCrashed = t.MaxCrashed
+		case config.SweepRequestPermutationOption:
+			permuteRequests = true
+		case config.SweepConcurrencyOption:
+			numConcurrent = t.N
+		case sweepRunOption:
+			runOpts = append(runOpts, t.opts...)
+		}
+	}
+
+	if len(nodeSets) == 0 {
+		panic("Sweep: At least one node set must be provided")
+	}
+
+	scenarios := []Scenario{}
+	for _, nodes := range nodeSets {
+		reqs := requests(nodes).request
+		requestOrders := [][]request.Request{reqs}
+		if permuteRequests {
+			requestOrders = permutations(reqs)
+		}
+		for _, crashed := range combinations(nodes, maxCrashed) {
+			for _, order := range requestOrders {
+				scenarios = append(scenarios, Scenario{
+					Nodes:        nodes,
+					CrashedNodes: crashed,
+					Requests:     order,
+				})
+			}
+		}
+	}
+
+	if numConcurrent < 1 {
+		numConcurrent = 1
+	}
+	if numConcurrent > len(scenarios) {
+		numConcurrent = len(scenarios)
+	}
+
+	results := make([]ScenarioResult, len(scenarios))
+	next := make(chan int)
+	wg := new(sync.WaitGroup)
+	for i := 0; i < numConcurrent; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sim := prepare()
+			for index := range next {
+				results[index] = runScenario(sim, scenarios[index], initNodes, checker, crashFunc, runOpts)
+			}
+		}()
+	}
+	for i := range scenarios {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+
+	report := SweepReport{Scenarios: len(scenarios)}
+	for _, res := range results {
+		if !res.Ok() {
+			report.Failed = append(report.Failed, res)
+		}
+	}
+	return report
+}
+
+// Simulate a single scenario.
+//
+// Panics that occur while running the simulation are recovered and returned as the error of the ScenarioResult.
+func runScenario[T, S any](sim Simulation[T, S], scenario Scenario, initNodes func(nodes []int) InitNodeOption[T], checker CheckerOption[S], crashFunc func(*T), runOpts []RunOptions) (res ScenarioResult) {
+	res.Scenario = scenario
+	defer func() {
+		if p := recover(); p != nil {
+			res.Err = fmt.Errorf("Sweep: Simulation of scenario panicked: %v", p)
+		}
+	}()
+	// The failure manager of the scenario is provided last, so that it replaces any failure manager in the RunOptions
+	opts := append(append([]RunOptions{}, runOpts...), WithPerfectFailureManager(crashFunc, scenario.CrashedNodes...))
+	res.Response = sim.Run(
+		initNodes(scenario.Nodes),
+		WithRequests(scenario.Requests...),
+		checker,
+		opts...,
+	)
+	return res
+}
+
+// Returns all combinations of at most k elements from the provided slice.
+//
+// The combinations are returned in order of increasing size.
+func combinations(elements []int, k int) [][]int {
+	out := [][]int{{}}
+	current := [][]int{{}}
+	for size := 1; size <= k && size <= len(elements); size++ {
+		next := [][]int{}
+		for _, comb := range current {
+			// Only extend with elements after the last element in the combination to avoid duplicates
+			start := 0
+			if len(comb) > 0 {
+				for i, elem := range elements {
+					if elem == comb[len(comb)-1] {
+						start = i + 1
+					}
+				}
+			}
+			for _, elem := range elements[start:] {
+				newComb := make([]int, len(comb), len(comb)+1)
+				copy(newComb, comb)
+				next = append(next, append(newComb, elem))
+			}
+		}
+		out = append(out, next...)
+		current = next
+	}
+	return out
+}
+
+// Returns all permutations of the provided requests.
+func permutations(reqs []request.Request) [][]request.Request {
+	if len(reqs) <= 1 {
+		return [][]request.Request{reqs}
+	}
+	out := [][]request.Request{}
+	for i, req := range reqs {
+		rest := make([]request.Request, 0, len(reqs)-1)
+		rest = append(rest, reqs[:i]...)
+		rest = append(rest, reqs[i+1:]...)
+		for _, perm := range permutations(rest) {
+			out = append(out, append([]request.Request{req}, perm...))
+		}
+	}
+	return out
+}
+
+// An option used to configure a sweep
+type SweepOption interface {
+	SweepOpt()
+}
+
+// Sweep the provided node sets.
+//
+// Each node set is a slice of node ids.
+// Can be called multiple times to add more node sets.
+func SweepNodes(nodeSets ...[]int) SweepOption {
+	return config.SweepNodesOption{Nodes: nodeSets}
+}
+
+// Sweep node sets with the provided number of nodes.
+//
+// A node set with n nodes has the ids 0 to n-1.
+func SweepNodeCounts(counts ...int) SweepOption {
+	nodeSets := make([][]int, len(counts))
+	for i, n := range counts {
+		nodes := make([]int, n)
+		for id := range nodes {
+			nodes[id] = id
+		}
+		nodeSets[i] = nodes
+	}
+	return config.SweepNodesOption{Nodes: nodeSets}
+}
+
+// Sweep all combinations of up to f failing nodes.
+//
+// crashFunc is a function performing the crash on the node.
+// The failing nodes are crashed using a PerfectFailureManager.
+//
+// Default value is no failing nodes.
+func SweepCrashes[T any](crashFunc func(*T), f int) SweepOption {
+	return config.SweepCrashOption[T]{CrashFunc: crashFunc, MaxCrashed: f}
+}
+
+// Sweep all permutations of the generated requests.
+//
+// Default is to only use the requests in the order that they are generated.
+func SweepRequestPermutations() SweepOption {
+	return config.SweepRequestPermutationOption{}
+}
+
+// Configure the number of scenarios that are simulated concurrently.
+//
+// Default value is GOMAXPROCS
+func SweepConcurrency(n int) SweepOption {
+	return config.SweepConcurrencyOption{N: n}
+}
+
+// Use the provided RunOptions when simulating each scenario.
+//
+// The failure manager is configured by the sweep. A failure manager provided in the RunOptions is replaced by the failure manager of each scenario.
+func SweepRunOptions(opts ...RunOptions) SweepOption {
+	return sweepRunOption{opts: opts}
+}
+
+// Stores the RunOptions used for all scenarios of a sweep
+type sweepRunOption struct {
+	opts []RunOptions
+}
+
+func (sro sweepRunOption) SweepOpt() {}
@@ -0,0 +1,62 @@
+package gomc_test
+
+import (
+	"gomc"
+	"gomc/checking"
+	"gomc/eventManager"
+	"testing"
+
+	"golang.org/x/exp/maps"
+)
+
+func runBoundedCrashes(predicate checking.Predicate[int]) checking.CheckerResponse {
+	sim := gomc.PrepareSimulation(
+		gomc.WithTreeStateManager(
+			func(node *ReceiverNode) int { return node.Received },
+			func(s1, s2 int) bool { return s1 == s2 },
+		),
+		gomc.PrefixScheduler(),
+		gomc.NumConcurrent(1),
+	)
+	return sim.Run(
+		gomc.InitSingleNode([]int{0, 1, 2},
+			func(id int, sp eventManager.SimulationParameters) *ReceiverNode {
+				return &ReceiverNode{send: eventManager.NewSender(sp).SendFunc(id)}
+			},
+		),
+		gomc.WithRequests(gomc.NewRequest(0, "Start")),
+		gomc.WithPredicateChecker(predicate),
+		gomc.WithBoundedCrashes(func(*ReceiverNode) {}, 1),
+	)
+}
+
+func TestBoundedCrashes(t *testing.T) {
+	atMostOne := func(s checking.State[int]) bool {
+		crashed := 0
+		for _, correct := range s.Correct {
+			if !correct {
+				crashed++
+			}
+		}
+		return crashed <= 1
+	}
+	if ok, desc := runBoundedCrashes(atMostOne).Response(); !ok {
+		t.Errorf("Expected at most one node to crash in each run. Got: %v", desc)
+	}
+
+	// Crashes are optional, so there is a finished run where no node crashes
+	crashedAtEnd := func(s checking.State[int]) bool {
+		return !s.IsTerminal || !maps.Equal(s.Correct, map[int]bool{0: true, 1: true, 2: true})
+	}
+	if ok, _ := runBoundedCrashes(crashedAtEnd).Response(); ok {
+		t.Errorf("Expected a finished run with fewer than maxCrashes crashes")
+	}
+
+	// Each of the nodes crashes in some run of the same simulation
+	for id := 0; id < 3; id++ {
+		id := id
+		if ok, _ := runBoundedCrashes(func(s checking.State[int]) bool { return s.Correct[id] }).Response(); ok {
+			t.Errorf("Expected a run where node %v crashes", id)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package gomc_test
+
+import (
+	"gomc"
+	"gomc/checking"
+	"gomc/eventManager"
+	"gomc/failureManager"
+	"testing"
+)
+
+// A node that broadcasts a value and stores the value it receives
+type ValueNode struct {
+	id    int
+	send  func(int, string, ...any)
+	nodes []int
+	Value int
+}
+
+func (n *ValueNode) Broadcast(val int) {
+	for _, id := range n.nodes {
+		if id != n.id {
+			n.send(id, "Deliver", val)
+		}
+	}
+}
+
+func (n *ValueNode) Deliver(val int) {
+	n.Value = val
+}
+
+func runByzantine(predicate checking.Predicate[int], mutations ...failureManager.Mutation) checking.CheckerResponse {
+	nodes := []int{0, 1, 2}
+	sim := gomc.PrepareSimulation(
+		gomc.WithTreeStateManager(
+			func(node *ValueNode) int { return node.Value },
+			func(s1, s2 int) bool { return s1 == s2 },
+		),
+		gomc.PrefixScheduler(),
+		gomc.NumConcurrent(1),
+	)
+	return sim.Run(
+		gomc.InitSingleNode(nodes,
+			func(id int, sp eventManager.SimulationParameters) *ValueNode {
+				return &ValueNode{id: id, send: eventManager.NewSender(sp).SendFunc(id), nodes: nodes}
+			},
+		),
+		gomc.WithRequests(gomc.NewRequest(0, "Broadcast", 1)),
+		gomc.WithPredicateChecker(predicate),
+		gomc.WithByzantineFailureManager[ValueNode](mutations, 1, 0),
+	)
+}
+
+func TestByzantineEquivocation(t *testing.T) {
+	// All correct nodes that have delivered a value agree on it
+	agreement := func(s checking.State[int]) bool {
+		if !s.IsTerminal {
+			return true
+		}
+		return checking.ForAllNodes(func(v int) bool { return v == s.LocalStates[1] }, s, true)
+	}
+	if ok, desc := runByzantine(agreement).Response(); ok {
+		t.Errorf("Expected a run where the Byzantine node omits a message. Got: %v", desc)
+	}
+
+	// Send the id of the receiver instead of the value
+	equivocate := func(from, to int, msgType string, params []any) ([]any, bool) {
+		return []any{to}, true
+	}
+	delivered := func(s checking.State[int]) bool {
+		return checking.ForAllNodes(func(v int) bool { return v == 0 || v == 1 }, s, true)
+	}
+	if ok, _ := runByzantine(delivered, equivocate).Response(); ok {
+		t.Errorf("Expected a run where the Byzantine node equivocates")
+	}
+}
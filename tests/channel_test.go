@@ -0,0 +1,97 @@
+package gomc_test
+
+import (
+	"gomc"
+	"gomc/checking"
+	"gomc/eventManager"
+	"testing"
+)
+
+// A node that sends values to the other node over channels, and records the values it receives.
+type ChanNode struct {
+	id   int
+	cm   *eventManager.ChannelManager[int]
+	ep   *eventManager.Endpoint[int]
+	quit chan struct{}
+
+	// The sum of the values received by the node
+	Sum int
+	// The last value received by the node
+	Last int
+}
+
+func (n *ChanNode) Send(val int) {
+	n.ep.Send(1-n.id, val)
+}
+
+func (n *ChanNode) receive() {
+	for {
+		select {
+		case env := <-n.ep.Recv():
+			n.Sum += env.Msg
+			n.Last = env.Msg
+		case <-n.quit:
+			return
+		}
+	}
+}
+
+func initChanNodes(sp eventManager.SimulationParameters) map[int]*ChanNode {
+	cm := eventManager.NewChannelManager[int](sp)
+	nodes := map[int]*ChanNode{}
+	for _, id := range []int{0, 1} {
+		node := &ChanNode{id: id, cm: cm, ep: cm.Endpoint(id), quit: make(chan struct{})}
+		go node.receive()
+		nodes[id] = node
+	}
+	return nodes
+}
+
+func runChan(pred checking.Predicate[[2]int], opts ...gomc.RunOptions) checking.CheckerResponse {
+	sim := gomc.PrepareSimulation(
+		gomc.WithTreeStateManager(
+			func(n *ChanNode) [2]int { return [2]int{n.Sum, n.Last} },
+			func(s1, s2 [2]int) bool { return s1 == s2 },
+		),
+		gomc.PrefixScheduler(),
+		gomc.NumConcurrent(1),
+	)
+	return sim.Run(
+		gomc.InitNodeFunc(initChanNodes),
+		gomc.WithRequests(
+			gomc.NewRequest(0, "Send", 1),
+			gomc.NewRequest(0, "Send", 2),
+		),
+		gomc.WithPredicateChecker(pred),
+		append(opts, gomc.WithStopFunctionSimulator(func(n *ChanNode) { close(n.quit) }))...,
+	)
+}
+
+func TestChannelManager(t *testing.T) {
+	resp := runChan(checking.Eventually(func(s checking.State[[2]int]) bool {
+		return s.LocalStates[1][0] == 3
+	}))
+	if ok, desc := resp.Response(); !ok {
+		t.Errorf("Expected all values to be received. Got: %v", desc)
+	}
+
+	// The values are not necessarily received in the order they were sent
+	resp = runChan(checking.Eventually(func(s checking.State[[2]int]) bool {
+		return s.LocalStates[1][1] == 2
+	}))
+	if ok, _ := resp.Response(); ok {
+		t.Errorf("Expected a run where the values are received in the reverse order")
+	}
+
+	// The values sent to the crashed node are discarded
+	resp = runChan(
+		checking.Eventually(func(s checking.State[[2]int]) bool {
+			return s.LocalStates[1][0] == 3 || !s.Correct[1]
+		}),
+		// The ChannelManager discards the messages once node 1 detects its own crash
+		gomc.WithPerfectFailureManager(func(n *ChanNode) {}, 1),
+	)
+	if ok, desc := resp.Response(); !ok {
+		t.Errorf("Expected the values to be received unless node 1 crashed. Got: %v", desc)
+	}
+}
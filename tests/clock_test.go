@@ -0,0 +1,55 @@
+package gomc_test
+
+import (
+	"gomc"
+	"gomc/checking"
+	"gomc/eventManager"
+	"testing"
+	"time"
+)
+
+// A node that records the time elapsed when its lease expires
+type LeaseNode struct {
+	id      int
+	clock   eventManager.Clock
+	timers  *eventManager.TimerManager
+	Elapsed time.Duration
+}
+
+func (n *LeaseNode) Start() {
+	start := n.clock.Now()
+	lease := start.Add(3 * time.Second)
+	n.timers.AfterFunc(n.id, 3*time.Second, func() {
+		if n.clock.Expired(lease) {
+			n.Elapsed = n.clock.Since(start)
+		}
+	})
+}
+
+func TestSimulatedClock(t *testing.T) {
+	sim := gomc.PrepareSimulation(
+		gomc.WithTreeStateManager(
+			func(node *LeaseNode) time.Duration { return node.Elapsed },
+			func(s1, s2 time.Duration) bool { return s1 == s2 },
+		),
+		gomc.PrefixScheduler(),
+		gomc.NumConcurrent(1),
+	)
+	resp := sim.Run(
+		gomc.InitSingleNode([]int{0, 1},
+			func(id int, sp eventManager.SimulationParameters) *LeaseNode {
+				return &LeaseNode{id: id, clock: sp.Clock, timers: eventManager.NewTimerManager(sp)}
+			},
+		),
+		gomc.WithRequests(gomc.NewRequest(0, "Start"), gomc.NewRequest(1, "Start")),
+		gomc.WithPredicateChecker(
+			checking.Eventually(func(s checking.State[time.Duration]) bool {
+				// Both nodes see at least the lease duration elapse before the lease expires
+				return s.LocalStates[0] >= 3*time.Second && s.LocalStates[1] >= 3*time.Second
+			}),
+		),
+	)
+	if ok, desc := resp.Response(); !ok {
+		t.Errorf("Expected the leases to expire in simulated time. Got: %v", desc)
+	}
+}
@@ -0,0 +1,91 @@
+package gomc_test
+
+import (
+	"gomc"
+	"gomc/checking"
+	"gomc/eventManager"
+	"testing"
+)
+
+type CounterNode struct {
+	Stable   int
+	Volatile int
+	crashed  bool
+}
+
+func (n *CounterNode) Inc() {
+	if n.crashed {
+		return
+	}
+	n.Stable++
+	n.Volatile++
+}
+
+type CounterState struct {
+	stable   int
+	volatile int
+}
+
+// Returns a predicate that is violated if the value returned by get decreases for some node in the run
+func neverDecreases(get func(CounterState) int) checking.Predicate[CounterState] {
+	return func(s checking.State[CounterState]) bool {
+		if len(s.Sequence) < 2 {
+			return true
+		}
+		prev := s.Sequence[len(s.Sequence)-2]
+		for id, state := range s.LocalStates {
+			if get(state) < get(prev.LocalStates[id]) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+func runCrashRecovery(predicate checking.Predicate[CounterState]) checking.CheckerResponse {
+	sim := gomc.PrepareSimulation(
+		gomc.WithTreeStateManager(
+			func(node *CounterNode) CounterState {
+				return CounterState{stable: node.Stable, volatile: node.Volatile}
+			},
+			func(s1, s2 CounterState) bool { return s1 == s2 },
+		),
+		gomc.PrefixScheduler(),
+		gomc.NumConcurrent(1),
+	)
+	return sim.Run(
+		gomc.InitSingleNode([]int{0, 1},
+			func(id int, sp eventManager.SimulationParameters) *CounterNode {
+				return &CounterNode{}
+			},
+		),
+		gomc.WithRequests(
+			gomc.NewRequest(0, "Inc"),
+			gomc.NewRequest(0, "Inc"),
+		),
+		gomc.WithPredicateChecker(predicate),
+		gomc.WithCrashRecoveryFailureManager(
+			func(n *CounterNode) { n.crashed = true },
+			func(n *CounterNode) int { return n.Stable },
+			func(id int, stable int, sp eventManager.SimulationParameters) *CounterNode {
+				return &CounterNode{Stable: stable}
+			},
+			2,
+			0,
+		),
+	)
+}
+
+func TestCrashRecoveryStableStorage(t *testing.T) {
+	// The stable storage survives crashes
+	resp := runCrashRecovery(neverDecreases(func(s CounterState) int { return s.stable }))
+	if ok, desc := resp.Response(); !ok {
+		t.Errorf("Expected the stable storage to survive crashes. Got: %v", desc)
+	}
+
+	// The volatile state is lost when the node crashes
+	resp = runCrashRecovery(neverDecreases(func(s CounterState) int { return s.volatile }))
+	if ok, _ := resp.Response(); ok {
+		t.Errorf("Expected the volatile state to be lost when the node recovers")
+	}
+}
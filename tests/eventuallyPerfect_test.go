@@ -0,0 +1,67 @@
+package gomc_test
+
+import (
+	"gomc"
+	"gomc/checking"
+	"gomc/eventManager"
+	"testing"
+)
+
+// A node that keeps track of the nodes it suspects
+type SuspectingNode struct {
+	// A bit mask of the suspected nodes
+	Suspected int
+	crashed   bool
+}
+
+func (n *SuspectingNode) Start() {}
+
+func runEventuallyPerfect(predicate checking.Predicate[int]) checking.CheckerResponse {
+	sim := gomc.PrepareSimulation(
+		gomc.WithTreeStateManager(
+			func(node *SuspectingNode) int { return node.Suspected },
+			func(s1, s2 int) bool { return s1 == s2 },
+		),
+		gomc.PrefixScheduler(),
+		gomc.NumConcurrent(1),
+	)
+	return sim.Run(
+		gomc.InitSingleNode([]int{0, 1, 2},
+			func(id int, sp eventManager.SimulationParameters) *SuspectingNode {
+				node := &SuspectingNode{}
+				sp.CrashSubscribe(id, func(suspected int, status bool) {
+					if node.crashed {
+						return
+					}
+					if status {
+						node.Suspected &^= 1 << suspected
+					} else {
+						node.Suspected |= 1 << suspected
+					}
+				})
+				return node
+			},
+		),
+		gomc.WithRequests(gomc.NewRequest(0, "Start")),
+		gomc.WithPredicateChecker(predicate),
+		gomc.WithEventuallyPerfectFailureManager(func(n *SuspectingNode) { n.crashed = true }, 1, 2),
+	)
+}
+
+func TestEventuallyPerfectFailureManager(t *testing.T) {
+	// Eventually the correct nodes suspect exactly the crashed node
+	eventuallyAccurate := checking.Eventually(func(s checking.State[int]) bool {
+		return checking.ForAllNodes(func(suspected int) bool { return suspected == 1<<2 }, s, true)
+	})
+	if ok, desc := runEventuallyPerfect(eventuallyAccurate).Response(); !ok {
+		t.Errorf("Expected the correct nodes to eventually suspect only the crashed node. Got: %v", desc)
+	}
+
+	// A correct node can be falsely suspected
+	neverSuspectCorrect := func(s checking.State[int]) bool {
+		return checking.ForAllNodes(func(suspected int) bool { return suspected&^(1<<2) == 0 }, s, true)
+	}
+	if ok, _ := runEventuallyPerfect(neverSuspectCorrect).Response(); ok {
+		t.Errorf("Expected a run where a correct node is falsely suspected")
+	}
+}
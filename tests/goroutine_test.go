@@ -0,0 +1,63 @@
+package gomc_test
+
+import (
+	"gomc"
+	"gomc/checking"
+	"gomc/eventManager"
+	"testing"
+)
+
+// A node that updates its state from goroutines started when handling a request
+type GoNode struct {
+	id int
+	gm *eventManager.GoroutineManager
+
+	// The values written by the goroutines, in the order they ran
+	Log string
+}
+
+func (n *GoNode) Start() {
+	for _, val := range []string{"a", "b"} {
+		val := val
+		n.gm.Go(n.id, func() { n.Log += val })
+	}
+}
+
+func initGoNodes(sp eventManager.SimulationParameters) map[int]*GoNode {
+	gm := eventManager.NewGoroutineManager(sp)
+	return map[int]*GoNode{0: {id: 0, gm: gm}}
+}
+
+func runGo(pred checking.Predicate[string]) checking.CheckerResponse {
+	sim := gomc.PrepareSimulation(
+		gomc.WithTreeStateManager(
+			func(n *GoNode) string { return n.Log },
+			func(s1, s2 string) bool { return s1 == s2 },
+		),
+		gomc.PrefixScheduler(),
+		gomc.NumConcurrent(1),
+	)
+	return sim.Run(
+		gomc.InitNodeFunc(initGoNodes),
+		gomc.WithRequests(gomc.NewRequest(0, "Start")),
+		gomc.WithPredicateChecker(pred),
+		gomc.WithStopFunctionSimulator(func(*GoNode) {}),
+	)
+}
+
+func TestGoroutineManager(t *testing.T) {
+	resp := runGo(checking.Eventually(func(s checking.State[string]) bool {
+		return len(s.LocalStates[0]) == 2
+	}))
+	if ok, desc := resp.Response(); !ok {
+		t.Errorf("Expected both goroutines to run. Got: %v", desc)
+	}
+
+	// Both orders of the goroutines are explored
+	resp = runGo(checking.Eventually(func(s checking.State[string]) bool {
+		return s.LocalStates[0] == "ab"
+	}))
+	if ok, _ := resp.Response(); ok {
+		t.Errorf("Expected a run where the goroutines run in the reverse order")
+	}
+}
@@ -0,0 +1,99 @@
+package gomc_test
+
+import (
+	"context"
+	"gomc"
+	"gomc/checking"
+	"gomc/eventManager"
+	"testing"
+
+	"google.golang.org/grpc"
+	testpb "google.golang.org/grpc/interop/grpc_testing"
+)
+
+// A node that opens a stream to node 1 and receives the responses in a separate goroutine
+type GrpcStreamNode struct {
+	testpb.UnimplementedTestServiceServer
+
+	srv    *grpc.Server
+	conn   *grpc.ClientConn
+	client testpb.TestServiceClient
+
+	// The total size of the received responses
+	Received int
+}
+
+func (n *GrpcStreamNode) StreamingOutputCall(req *testpb.StreamingOutputCallRequest, stream testpb.TestService_StreamingOutputCallServer) error {
+	for _, p := range req.GetResponseParameters() {
+		stream.Send(&testpb.StreamingOutputCallResponse{Payload: &testpb.Payload{Body: make([]byte, p.GetSize())}})
+	}
+	return nil
+}
+
+func (n *GrpcStreamNode) Start() {
+	stream, err := n.client.StreamingOutputCall(context.Background(), &testpb.StreamingOutputCallRequest{
+		ResponseParameters: []*testpb.ResponseParameters{{Size: 1}, {Size: 2}},
+	})
+	if err != nil {
+		return
+	}
+	go func() {
+		for {
+			resp, err := stream.Recv()
+			if err != nil {
+				return
+			}
+			n.Received += len(resp.GetPayload().GetBody())
+		}
+	}()
+}
+
+func initGrpcStreamNodes(sp eventManager.SimulationParameters) map[int]*GrpcStreamNode {
+	gem := eventManager.NewGrpcEventManager(sp)
+	nodes := map[int]*GrpcStreamNode{}
+	for _, id := range []int{0, 1} {
+		node := &GrpcStreamNode{srv: grpc.NewServer(grpc.StreamInterceptor(gem.StreamServerControllerInterceptor()))}
+		testpb.RegisterTestServiceServer(node.srv, node)
+		go node.srv.Serve(gem.Listen(id))
+
+		conn, err := gem.Dial(1-id, grpc.WithStreamInterceptor(gem.StreamClientControllerInterceptor(id)))
+		if err != nil {
+			panic(err)
+		}
+		node.conn = conn
+		node.client = testpb.NewTestServiceClient(conn)
+		nodes[id] = node
+	}
+	return nodes
+}
+
+func TestGrpcStream(t *testing.T) {
+	sim := gomc.PrepareSimulation(
+		gomc.WithTreeStateManager(
+			func(node *GrpcStreamNode) int { return node.Received },
+			func(s1, s2 int) bool { return s1 == s2 },
+		),
+		gomc.PrefixScheduler(),
+		gomc.NumConcurrent(1),
+	)
+	resp := sim.Run(
+		gomc.InitNodeFunc(initGrpcStreamNodes),
+		gomc.WithRequests(gomc.NewRequest(0, "Start")),
+		gomc.WithPredicateChecker(
+			checking.Eventually(func(s checking.State[int]) bool {
+				return s.LocalStates[0] == 3
+			}),
+			func(s checking.State[int]) bool {
+				// The responses are received in order
+				return s.LocalStates[0] != 2
+			},
+		),
+		gomc.WithStopFunctionSimulator(func(n *GrpcStreamNode) {
+			n.conn.Close()
+			n.srv.Stop()
+		}),
+	)
+	if ok, desc := resp.Response(); !ok {
+		t.Errorf("Expected all responses to be received in order. Got: %v", desc)
+	}
+}
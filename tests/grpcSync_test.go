@@ -0,0 +1,92 @@
+package gomc_test
+
+import (
+	"context"
+	"gomc"
+	"gomc/checking"
+	"gomc/eventManager"
+	"testing"
+
+	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// A node that makes a synchronous gRPC call to the health server of node 1
+type GrpcSyncNode struct {
+	healthpb.UnimplementedHealthServer
+
+	srv    *grpc.Server
+	conn   *grpc.ClientConn
+	client healthpb.HealthClient
+
+	// The number of calls handled by the node
+	Calls int
+	// The result of the call made by the node. 1 if the call succeeded, -1 if it failed.
+	Result int
+}
+
+func (n *GrpcSyncNode) Check(ctx context.Context, in *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+	n.Calls++
+	return &healthpb.HealthCheckResponse{Status: healthpb.HealthCheckResponse_SERVING}, nil
+}
+
+func (n *GrpcSyncNode) Start() {
+	resp, err := n.client.Check(context.Background(), &healthpb.HealthCheckRequest{})
+	if err != nil || resp.GetStatus() != healthpb.HealthCheckResponse_SERVING {
+		n.Result = -1
+		return
+	}
+	n.Result = 1
+}
+
+func initGrpcSyncNodes(sp eventManager.SimulationParameters) map[int]*GrpcSyncNode {
+	gem := eventManager.NewGrpcEventManager(sp)
+	nodes := map[int]*GrpcSyncNode{}
+	for _, id := range []int{0, 1} {
+		node := &GrpcSyncNode{srv: grpc.NewServer()}
+		healthpb.RegisterHealthServer(node.srv, node)
+		go node.srv.Serve(gem.Listen(id))
+
+		conn, err := gem.Dial(1-id, grpc.WithUnaryInterceptor(gem.SyncUnaryClientControllerInterceptor(id)))
+		if err != nil {
+			panic(err)
+		}
+		node.conn = conn
+		node.client = healthpb.NewHealthClient(conn)
+		nodes[id] = node
+	}
+	return nodes
+}
+
+func runGrpcSync(opts ...gomc.RunOptions) checking.CheckerResponse {
+	sim := gomc.PrepareSimulation(
+		gomc.WithTreeStateManager(
+			func(node *GrpcSyncNode) [2]int { return [2]int{node.Calls, node.Result} },
+			func(s1, s2 [2]int) bool { return s1 == s2 },
+		),
+		gomc.PrefixScheduler(),
+		gomc.NumConcurrent(1),
+	)
+	return sim.Run(
+		gomc.InitNodeFunc(initGrpcSyncNodes),
+		gomc.WithRequests(gomc.NewRequest(0, "Start")),
+		gomc.WithPredicateChecker(
+			checking.Eventually(func(s checking.State[[2]int]) bool {
+				return s.LocalStates[0][1] == 1 && s.LocalStates[1][0] == 1
+			}),
+		),
+		append(opts, gomc.WithStopFunctionSimulator(func(n *GrpcSyncNode) {
+			n.conn.Close()
+			n.srv.Stop()
+		}))...,
+	)
+}
+
+func TestGrpcSyncCall(t *testing.T) {
+	if ok, desc := runGrpcSync().Response(); !ok {
+		t.Errorf("Expected the call to be handled and the response to be received. Got: %v", desc)
+	}
+	if ok, _ := runGrpcSync(gomc.WithNetworkFaults(1, 0)).Response(); ok {
+		t.Errorf("Expected a run where the request is lost")
+	}
+}
@@ -0,0 +1,92 @@
+package gomc_test
+
+import (
+	"gomc"
+	"gomc/checking"
+	"gomc/eventManager"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// A node that replicates a value to node 1 using HTTP requests
+type HttpNode struct {
+	srv    *http.Server
+	client *http.Client
+	url    string
+
+	// The value stored by the node
+	Value string
+	// The result of the request made by the node. 1 if the value was replicated, -1 if the request failed.
+	Result int
+}
+
+func (n *HttpNode) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	n.Value = string(body)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (n *HttpNode) Put(value string) {
+	n.Value = value
+	resp, err := n.client.Post(n.url+"/value", "text/plain", strings.NewReader(value))
+	if err != nil || resp.StatusCode != http.StatusNoContent {
+		n.Result = -1
+		return
+	}
+	n.Result = 1
+}
+
+func initHttpNodes(sp eventManager.SimulationParameters) map[int]*HttpNode {
+	hem := eventManager.NewHttpEventManager(sp)
+	nodes := map[int]*HttpNode{}
+	for _, id := range []int{0, 1} {
+		node := &HttpNode{
+			client: &http.Client{Transport: hem.Transport(id)},
+			url:    hem.URL(1 - id),
+		}
+		node.srv = &http.Server{Handler: node}
+		go node.srv.Serve(hem.Listen(id))
+		nodes[id] = node
+	}
+	return nodes
+}
+
+type httpState struct {
+	value  string
+	result int
+}
+
+func runHttp(opts ...gomc.RunOptions) checking.CheckerResponse {
+	sim := gomc.PrepareSimulation(
+		gomc.WithTreeStateManager(
+			func(node *HttpNode) httpState { return httpState{node.Value, node.Result} },
+			func(s1, s2 httpState) bool { return s1 == s2 },
+		),
+		gomc.PrefixScheduler(),
+		gomc.NumConcurrent(1),
+	)
+	return sim.Run(
+		gomc.InitNodeFunc(initHttpNodes),
+		gomc.WithRequests(gomc.NewRequest(0, "Put", "x")),
+		gomc.WithPredicateChecker(
+			checking.Eventually(func(s checking.State[httpState]) bool {
+				return s.LocalStates[0].result == 1 && s.LocalStates[1].value == "x"
+			}),
+		),
+		append(opts, gomc.WithStopFunctionSimulator(func(n *HttpNode) {
+			n.srv.Close()
+			n.client.CloseIdleConnections()
+		}))...,
+	)
+}
+
+func TestHttpEventManager(t *testing.T) {
+	if ok, desc := runHttp().Response(); !ok {
+		t.Errorf("Expected the value to be replicated. Got: %v", desc)
+	}
+	if ok, _ := runHttp(gomc.WithNetworkFaults(1, 0)).Response(); ok {
+		t.Errorf("Expected a run where the request is lost")
+	}
+}
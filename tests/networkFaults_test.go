@@ -0,0 +1,58 @@
+package gomc_test
+
+import (
+	"gomc"
+	"gomc/checking"
+	"gomc/eventManager"
+	"testing"
+)
+
+type ReceiverNode struct {
+	send     func(int, string, ...any)
+	Received int
+}
+
+func (n *ReceiverNode) Start() {
+	n.send(1, "Receive")
+}
+
+func (n *ReceiverNode) Receive() {
+	n.Received++
+}
+
+func runNetworkFaults(opts ...gomc.RunOptions) checking.CheckerResponse {
+	sim := gomc.PrepareSimulation(
+		gomc.WithTreeStateManager(
+			func(node *ReceiverNode) int { return node.Received },
+			func(s1, s2 int) bool { return s1 == s2 },
+		),
+		gomc.PrefixScheduler(),
+		gomc.NumConcurrent(1),
+	)
+	return sim.Run(
+		gomc.InitSingleNode([]int{0, 1},
+			func(id int, sp eventManager.SimulationParameters) *ReceiverNode {
+				return &ReceiverNode{send: eventManager.NewSender(sp).SendFunc(id)}
+			},
+		),
+		gomc.WithRequests(gomc.NewRequest(0, "Start")),
+		gomc.WithPredicateChecker(
+			checking.Eventually(func(s checking.State[int]) bool {
+				return s.LocalStates[1] == 1
+			}),
+		),
+		opts...,
+	)
+}
+
+func TestNetworkFaults(t *testing.T) {
+	if ok, desc := runNetworkFaults().Response(); !ok {
+		t.Errorf("Expected the message to be delivered exactly once on a reliable network. Got: %v", desc)
+	}
+	if ok, _ := runNetworkFaults(gomc.WithNetworkFaults(1, 0)).Response(); ok {
+		t.Errorf("Expected a run where the message is lost")
+	}
+	if ok, _ := runNetworkFaults(gomc.WithNetworkFaults(0, 1)).Response(); ok {
+		t.Errorf("Expected a run where the message is duplicated")
+	}
+}
@@ -0,0 +1,161 @@
+package gomc_test
+
+import (
+	"errors"
+	"gomc"
+	"gomc/checking"
+	"gomc/eventManager"
+	"gomc/runner"
+	"io"
+	"net"
+	"testing"
+	"time"
+)
+
+// A node that sends values to the other node over an in-memory connection, and sums the values it receives.
+//
+// The node reads from all its connections to detect when they are reset.
+type NetNode struct {
+	id   int
+	nm   *eventManager.NetworkManager
+	conn net.Conn
+
+	// The sum of the values received by the node
+	Sum int
+	// The number of connections that have been reset
+	Resets int
+}
+
+func (n *NetNode) Send(val int) {
+	n.conn.Write([]byte{byte(val)})
+}
+
+func (n *NetNode) serve(lis net.Listener) {
+	for {
+		conn, err := lis.Accept()
+		if err != nil {
+			return
+		}
+		go n.read(conn)
+	}
+}
+
+func (n *NetNode) read(conn net.Conn) {
+	defer conn.Close()
+	buf := make([]byte, 1)
+	for {
+		_, err := conn.Read(buf)
+		if err != nil {
+			if !errors.Is(err, io.EOF) && !errors.Is(err, net.ErrClosed) {
+				n.Resets++
+			}
+			return
+		}
+		n.Sum += int(buf[0])
+	}
+}
+
+func initNetNodes(sp eventManager.SimulationParameters) map[int]*NetNode {
+	nm := eventManager.NewNetworkManager(sp)
+	nodes := map[int]*NetNode{}
+	for _, id := range []int{0, 1} {
+		node := &NetNode{id: id, nm: nm}
+		go node.serve(nm.Listen(id))
+		conn, err := nm.Dial(id, 1-id)
+		if err != nil {
+			panic(err)
+		}
+		node.conn = conn
+		go node.read(conn)
+		nodes[id] = node
+	}
+	return nodes
+}
+
+func netState(n *NetNode) [2]int {
+	return [2]int{n.Sum, n.Resets}
+}
+
+func TestNetworkManager(t *testing.T) {
+	sim := gomc.PrepareSimulation(
+		gomc.WithTreeStateManager(netState, func(s1, s2 [2]int) bool { return s1 == s2 }),
+		gomc.PrefixScheduler(),
+		gomc.NumConcurrent(1),
+	)
+	resp := sim.Run(
+		gomc.InitNodeFunc(initNetNodes),
+		gomc.WithRequests(
+			gomc.NewRequest(0, "Send", 1),
+			gomc.NewRequest(0, "Send", 2),
+			gomc.NewRequest(1, "Send", 4),
+		),
+		gomc.WithPredicateChecker(
+			checking.Eventually(func(s checking.State[[2]int]) bool {
+				return s.LocalStates[0][0] == 4 && s.LocalStates[1][0] == 3
+			}),
+		),
+		gomc.WithStopFunctionSimulator(func(n *NetNode) { n.nm.Close() }),
+	)
+	if ok, desc := resp.Response(); !ok {
+		t.Errorf("Expected all values to be received. Got: %v", desc)
+	}
+
+	resp = sim.Run(
+		gomc.InitNodeFunc(initNetNodes),
+		gomc.WithRequests(gomc.NewRequest(1, "Send", 4)),
+		gomc.WithPredicateChecker(
+			checking.Eventually(func(s checking.State[[2]int]) bool {
+				// Both connections of node 0 are reset
+				return s.LocalStates[0][1] == 2
+			}),
+		),
+		// The NetworkManager crashes the node when it detects its own crash
+		gomc.WithPerfectFailureManager(func(n *NetNode) {}, 1),
+		gomc.WithStopFunctionSimulator(func(n *NetNode) { n.nm.Close() }),
+	)
+	if ok, desc := resp.Response(); !ok {
+		t.Errorf("Expected node 0 to observe the crash of node 1. Got: %v", desc)
+	}
+}
+
+// Wait until the runner records that the node has reached the state
+func waitForNetState(t *testing.T, records <-chan runner.Record, id int, state [2]int) {
+	t.Helper()
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case rec := <-records:
+			if s, ok := rec.(runner.StateRecord[[2]int]); ok && s.Target() == id && s.State == state {
+				return
+			}
+		case <-timeout:
+			t.Fatalf("Timed out waiting for node %v to reach state %v", id, state)
+		}
+	}
+}
+
+func TestNetworkManagerRunner(t *testing.T) {
+	var nm *eventManager.NetworkManager
+	r := gomc.PrepareRunner(
+		gomc.InitNodeFunc(func(sp eventManager.SimulationParameters) map[int]*NetNode {
+			nodes := initNetNodes(sp)
+			nm = nodes[0].nm
+			return nodes
+		}),
+		gomc.WithStateFunction(netState),
+		gomc.WithStopFunctionRunner(func(n *NetNode) { n.nm.Crash(n.id) }),
+	)
+	defer nm.Close()
+	defer r.Stop()
+	records := r.SubscribeRecords()
+
+	if err := r.Request(gomc.NewRequest(0, "Send", 2)); err != nil {
+		t.Fatalf("Unexpected error sending request: %v", err)
+	}
+	waitForNetState(t, records, 1, [2]int{2, 0})
+
+	if err := r.CrashNode(1); err != nil {
+		t.Fatalf("Unexpected error crashing node: %v", err)
+	}
+	waitForNetState(t, records, 0, [2]int{0, 2})
+}
@@ -0,0 +1,56 @@
+package gomc_test
+
+import (
+	"gomc"
+	"gomc/checking"
+	"gomc/eventManager"
+	"testing"
+)
+
+type LeaderNode struct {
+	Leader  int
+	crashed bool
+}
+
+func (n *LeaderNode) Start() {}
+
+func TestOmegaEventualLeader(t *testing.T) {
+	sim := gomc.PrepareSimulation(
+		gomc.WithTreeStateManager(
+			func(node *LeaderNode) int { return node.Leader },
+			func(s1, s2 int) bool { return s1 == s2 },
+		),
+		gomc.PrefixScheduler(),
+		gomc.NumConcurrent(1),
+	)
+	resp := sim.Run(
+		gomc.InitSingleNode([]int{0, 1, 2},
+			func(id int, sp eventManager.SimulationParameters) *LeaderNode {
+				node := &LeaderNode{Leader: -1}
+				sp.LeaderSubscribe(id, func(leader int) {
+					if !node.crashed {
+						node.Leader = leader
+					}
+				})
+				return node
+			},
+		),
+		gomc.WithRequests(gomc.NewRequest(0, "Start")),
+		gomc.WithPredicateChecker(
+			checking.Eventually(func(s checking.State[int]) bool {
+				// All correct nodes trust the same correct leader
+				leader := -1
+				return checking.ForAllNodes(func(l int) bool {
+					if leader == -1 {
+						leader = l
+					}
+					return l == leader && s.Correct[l]
+				}, s, true)
+			}),
+		),
+		gomc.WithOmegaFailureManager(func(n *LeaderNode) { n.crashed = true }, 1, 0),
+	)
+	if ok, desc := resp.Response(); !ok {
+		t.Errorf("Expected a single correct leader to eventually be trusted. Got: %v", desc)
+	}
+}
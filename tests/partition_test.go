@@ -0,0 +1,82 @@
+package gomc_test
+
+import (
+	"gomc"
+	"gomc/checking"
+	"gomc/eventManager"
+	"testing"
+)
+
+func runPartition(partitions [][][]int, predicate checking.Predicate[int], opts ...gomc.RunOptions) checking.CheckerResponse {
+	sim := gomc.PrepareSimulation(
+		gomc.WithTreeStateManager(
+			func(node *ReceiverNode) int { return node.Received },
+			func(s1, s2 int) bool { return s1 == s2 },
+		),
+		gomc.PrefixScheduler(),
+		gomc.NumConcurrent(1),
+	)
+	return sim.Run(
+		gomc.InitSingleNode([]int{0, 1, 2},
+			func(id int, sp eventManager.SimulationParameters) *ReceiverNode {
+				return &ReceiverNode{send: eventManager.NewSender(sp).SendFunc(id)}
+			},
+		),
+		gomc.WithRequests(gomc.NewRequest(0, "Start")),
+		gomc.WithPredicateChecker(predicate),
+		append(opts, gomc.WithPartitionFailureManager(func(*ReceiverNode) {}, partitions, 1))...,
+	)
+}
+
+func TestPartitionDropsMessages(t *testing.T) {
+	delivered := checking.Eventually(func(s checking.State[int]) bool {
+		return s.LocalStates[1] == 1
+	})
+	// Node 2 is isolated, which does not affect the message from node 0 to node 1
+	if ok, desc := runPartition([][][]int{{{2}}}, delivered).Response(); !ok {
+		t.Errorf("Expected the message to be delivered. Got: %v", desc)
+	}
+	// Node 0 is isolated from node 1
+	if ok, _ := runPartition([][][]int{{{0}, {1, 2}}}, delivered).Response(); ok {
+		t.Errorf("Expected a run where the message is dropped by the partition")
+	}
+}
+
+func TestPartitionState(t *testing.T) {
+	// The message is only received while node 0 and 1 are connected
+	receivedWhileConnected := func(s checking.State[int]) bool {
+		if len(s.Sequence) < 2 {
+			return true
+		}
+		prev := s.Sequence[len(s.Sequence)-2]
+		if s.LocalStates[1] > prev.LocalStates[1] {
+			return s.Connected(0, 1)
+		}
+		return true
+	}
+	if ok, desc := runPartition([][][]int{{{0}, {1, 2}}}, receivedWhileConnected).Response(); !ok {
+		t.Errorf("Expected messages to only be received while the nodes are connected. Got: %v", desc)
+	}
+
+	partitioned := func(s checking.State[int]) bool {
+		return s.Partition == nil
+	}
+	if ok, _ := runPartition([][][]int{{{0}}}, partitioned).Response(); ok {
+		t.Errorf("Expected a state where the network is partitioned")
+	}
+}
+
+func TestPartitionReplay(t *testing.T) {
+	delivered := checking.Eventually(func(s checking.State[int]) bool {
+		return s.LocalStates[1] == 1
+	})
+	resp := runPartition([][][]int{{{0}, {1, 2}}}, delivered)
+	if ok, _ := resp.Response(); ok {
+		t.Fatalf("Expected a run where the message is dropped by the partition")
+	}
+
+	// The dropped message is recorded with its own id, so the counterexample can be replayed
+	if ok, _ := runPartition([][][]int{{{0}, {1, 2}}}, delivered, gomc.ReplayRun(resp.Export())).Response(); ok {
+		t.Errorf("Expected the replayed run to drop the message")
+	}
+}
@@ -0,0 +1,65 @@
+package gomc_test
+
+import (
+	"gomc"
+	"gomc/checking"
+	"gomc/eventManager"
+	"math/rand"
+	"testing"
+)
+
+// A node that picks a random leader
+type RandomLeaderNode struct {
+	rand   *rand.Rand
+	Leader int
+}
+
+func (n *RandomLeaderNode) Elect() {
+	n.Leader = n.rand.Intn(3)
+}
+
+func runRandomLeader(newRandom func(sp eventManager.SimulationParameters) *eventManager.RandomManager, opts ...gomc.RunOptions) checking.CheckerResponse {
+	sim := gomc.PrepareSimulation(
+		gomc.WithTreeStateManager(
+			func(node *RandomLeaderNode) int { return node.Leader },
+			func(s1, s2 int) bool { return s1 == s2 },
+		),
+		gomc.PrefixScheduler(),
+		gomc.NumConcurrent(1),
+	)
+	return sim.Run(
+		gomc.InitNodeFunc(func(sp eventManager.SimulationParameters) map[int]*RandomLeaderNode {
+			rm := newRandom(sp)
+			return map[int]*RandomLeaderNode{0: {rand: rand.New(rm.Source(0)), Leader: -1}}
+		}),
+		gomc.WithRequests(gomc.NewRequest(0, "Elect")),
+		gomc.WithPredicateChecker(func(s checking.State[int]) bool {
+			return s.LocalStates[0] != 2
+		}),
+		opts...,
+	)
+}
+
+func TestRandomManager(t *testing.T) {
+	explore := func(sp eventManager.SimulationParameters) *eventManager.RandomManager {
+		return eventManager.NewRandomManager(sp, 3)
+	}
+	resp := runRandomLeader(explore)
+	if ok, _ := resp.Response(); ok {
+		t.Fatalf("Expected the run where node 2 is elected to be explored")
+	}
+
+	// The outcome of the draw is recorded in the run
+	if ok, _ := runRandomLeader(explore, gomc.ReplayRun(resp.Export())).Response(); ok {
+		t.Errorf("Expected the replayed run to elect node 2")
+	}
+
+	// With a seed, the outcome is the same in every run
+	seeded := func(sp eventManager.SimulationParameters) *eventManager.RandomManager {
+		return eventManager.NewSeededRandomManager(sp, 1)
+	}
+	ok, _ := runRandomLeader(seeded).Response()
+	if expected := rand.New(rand.NewSource(1)).Intn(3); ok != (expected != 2) {
+		t.Errorf("Expected the seeded draw to elect node %v", expected)
+	}
+}
@@ -0,0 +1,90 @@
+package gomc_test
+
+import (
+	"fmt"
+	"gomc"
+	"gomc/checking"
+	"gomc/replay"
+	"gomc/scheduler"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestReplayArtifact(t *testing.T) {
+	nodes := []int{0, 1, 2}
+	requests := gomc.WithRequests(gomc.NewRequest(0, "Broadcast", []byte("Test Message")))
+	predicate := func(s checking.State[BroadcastState]) bool {
+		return checking.ForAllNodes(func(s BroadcastState) bool { return s.acked < 3 }, s, false)
+	}
+	cfg := gomc.ReplayConfig(nodes, []int{}, requests, 0, predicate)
+
+	resp := prepareBroadcastSimulation().Run(initBroadcastNodes(nodes), requests, gomc.WithPredicateChecker(predicate))
+	if ok, _ := resp.Response(); ok {
+		t.Fatalf("Expected the simulation to fail")
+	}
+
+	path := filepath.Join(t.TempDir(), "replay.json")
+	if err := replay.New(cfg, resp).Save(path); err != nil {
+		t.Fatalf("Unable to save artifact: %v", err)
+	}
+
+	a, err := replay.Load(path, cfg)
+	if err != nil {
+		t.Fatalf("Unable to load artifact: %v", err)
+	}
+	resp = prepareBroadcastSimulation().Run(initBroadcastNodes(nodes), requests, gomc.WithPredicateChecker(predicate), gomc.ReplayArtifact(a))
+	if ok, _ := resp.Response(); ok {
+		t.Errorf("Expected the replayed run to fail")
+	}
+
+	// The artifact can not be loaded using a different scenario
+	otherRequests := gomc.WithRequests(gomc.NewRequest(1, "Broadcast", []byte("Test Message")))
+	_, err = replay.Load(path, gomc.ReplayConfig(nodes, []int{}, otherRequests, 0, predicate))
+	if err == nil || !strings.Contains(err.Error(), "requests") {
+		t.Errorf("Expected the requests to mismatch. Got: %v", err)
+	}
+}
+
+func TestReplayDivergence(t *testing.T) {
+	nodes := []int{0, 1}
+	requests := gomc.WithRequests(gomc.NewRequest(0, "Broadcast", []byte("Test Message")))
+	checker := gomc.WithPredicateChecker(func(s checking.State[BroadcastState]) bool {
+		return checking.ForAllNodes(func(s BroadcastState) bool { return s.acked < 2 }, s, false)
+	})
+
+	resp := prepareBroadcastSimulation().Run(initBroadcastNodes(nodes), requests, checker)
+	run := resp.Export()
+	if len(run) < 2 {
+		t.Fatalf("Expected the simulation to fail with a run. Got: %v", run)
+	}
+	// Change the second event so that the run can not be followed
+	run[1] = run[1] + "x"
+
+	func() {
+		defer func() {
+			p := recover()
+			if p == nil {
+				t.Errorf("Expected the replay to fail")
+				return
+			}
+			for _, expected := range []string{"diverged at event 2", "Closest pending event", "States leading up to the divergence"} {
+				if !strings.Contains(fmt.Sprint(p), expected) {
+					t.Errorf("Expected the error to contain %q. Got: %v", expected, p)
+				}
+			}
+		}()
+		prepareBroadcastSimulation().Run(initBroadcastNodes(nodes), requests, checker, gomc.ReplayRun(run))
+	}()
+
+	divergences := []scheduler.DivergenceError{}
+	resp = prepareBroadcastSimulation().Run(initBroadcastNodes(nodes), requests, checker, gomc.BestEffortReplayRun(run, func(de scheduler.DivergenceError) {
+		divergences = append(divergences, de)
+	}))
+	if ok, _ := resp.Response(); ok {
+		t.Errorf("Expected the best effort replay to follow the closest event and fail")
+	}
+	if len(divergences) != 1 || divergences[0].Step != 2 {
+		t.Errorf("Expected the divergence at the second event to be reported. Got: %v", divergences)
+	}
+}
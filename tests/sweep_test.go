@@ -0,0 +1,133 @@
+package gomc_test
+
+import (
+	"gomc"
+	"gomc/checking"
+	"gomc/eventManager"
+	"testing"
+)
+
+func prepareBroadcastSimulation() gomc.Simulation[BroadcastNode, BroadcastState] {
+	return gomc.PrepareSimulation(
+		gomc.WithTreeStateManager(
+			func(node *BroadcastNode) BroadcastState {
+				return BroadcastState{
+					delivered: node.Delivered,
+					acked:     node.Acked,
+				}
+			},
+			func(s1, s2 BroadcastState) bool {
+				return s1 == s2
+			},
+		),
+		gomc.PrefixScheduler(),
+		gomc.NumConcurrent(1),
+		gomc.MaxRuns(500),
+	)
+}
+
+func initBroadcastNodes(nodeIds []int) gomc.InitNodeOption[BroadcastNode] {
+	return gomc.InitNodeFunc(
+		func(sp eventManager.SimulationParameters) map[int]*BroadcastNode {
+			send := eventManager.NewSender(sp)
+			nodes := map[int]*BroadcastNode{}
+			for _, id := range nodeIds {
+				nodes[id] = &BroadcastNode{
+					Id:    id,
+					send:  send.SendFunc(id),
+					nodes: nodeIds,
+				}
+			}
+			return nodes
+		},
+	)
+}
+
+func TestSweep(t *testing.T) {
+	report := gomc.Sweep(
+		prepareBroadcastSimulation,
+		initBroadcastNodes,
+		func(nodes []int) gomc.RequestOption {
+			return gomc.WithRequests(gomc.NewRequest(0, "Broadcast", []byte("Test Message")))
+		},
+		gomc.WithPredicateChecker(
+			func(s checking.State[BroadcastState]) bool {
+				// Is violated when there are at least 3 nodes
+				return checking.ForAllNodes(func(s BroadcastState) bool { return s.acked < 3 }, s, false)
+			},
+		),
+		gomc.SweepNodeCounts(2, 3),
+		gomc.SweepCrashes(func(*BroadcastNode) {}, 1),
+		gomc.SweepConcurrency(2),
+	)
+
+	// 3 crash combinations with 2 nodes and 4 with 3 nodes
+	if report.Scenarios != 7 {
+		t.Errorf("Unexpected number of scenarios. Got: %v. Expected: %v", report.Scenarios, 7)
+	}
+	if len(report.Failed) != 4 {
+		t.Errorf("Unexpected number of failed scenarios. Got: %v. Expected: %v", len(report.Failed), 4)
+	}
+	for _, res := range report.Failed {
+		if len(res.Scenario.Nodes) != 3 {
+			t.Errorf("Did not expect scenario to fail: %v", res.Scenario)
+		}
+	}
+	if ok, _ := report.Response(); ok {
+		t.Errorf("Expected the sweep to fail")
+	}
+}
+
+func TestSweepFailureManagerNotOverridden(t *testing.T) {
+	report := gomc.Sweep(
+		prepareBroadcastSimulation,
+		initBroadcastNodes,
+		func(nodes []int) gomc.RequestOption {
+			return gomc.WithRequests(gomc.NewRequest(0, "Broadcast", []byte("Test Message")))
+		},
+		gomc.WithPredicateChecker(
+			func(s checking.State[BroadcastState]) bool {
+				// Is violated when a node crashes
+				for _, correct := range s.Correct {
+					if !correct {
+						return false
+					}
+				}
+				return true
+			},
+		),
+		gomc.SweepNodes([]int{0, 1}),
+		gomc.SweepCrashes(func(*BroadcastNode) {}, 1),
+		// The failure manager of the scenarios crashes the nodes, even if another failure manager is provided
+		gomc.SweepRunOptions(gomc.WithPerfectFailureManager(func(*BroadcastNode) {})),
+	)
+
+	if len(report.Failed) != 2 {
+		t.Errorf("Expected the scenarios where a node crashes to fail. Got: %v", report.Failed)
+	}
+}
+
+func TestSweepRequestPermutations(t *testing.T) {
+	report := gomc.Sweep(
+		prepareBroadcastSimulation,
+		initBroadcastNodes,
+		func(nodes []int) gomc.RequestOption {
+			return gomc.WithRequests(
+				gomc.NewRequest(0, "Broadcast", []byte("1")),
+				gomc.NewRequest(1, "Broadcast", []byte("2")),
+				gomc.NewRequest(1, "Broadcast", []byte("3")),
+			)
+		},
+		gomc.WithPredicateChecker[BroadcastState](),
+		gomc.SweepNodes([]int{0, 1}),
+		gomc.SweepRequestPermutations(),
+		gomc.SweepRunOptions(gomc.WithStopFunctionSimulator(func(*BroadcastNode) {})),
+	)
+
+	if report.Scenarios != 6 {
+		t.Errorf("Unexpected number of scenarios. Got: %v. Expected: %v", report.Scenarios, 6)
+	}
+	if ok, desc := report.Response(); !ok {
+		t.Errorf("Expected all scenarios to pass. Got: %v", desc)
+	}
+}
@@ -0,0 +1,55 @@
+package gomc_test
+
+import (
+	"gomc"
+	"gomc/checking"
+	"gomc/eventManager"
+	"testing"
+	"time"
+)
+
+// A node with two timers, where the timer that fires first stops the other
+type TimerNode struct {
+	id     int
+	timers *eventManager.TimerManager
+	Fired  int
+}
+
+func (n *TimerNode) Start() {
+	var t1, t2 *eventManager.Timer
+	t1 = n.timers.AfterFunc(n.id, time.Second, func() {
+		n.Fired++
+		t2.Stop()
+	})
+	t2 = n.timers.AfterFunc(n.id, 2*time.Second, func() {
+		n.Fired++
+		t1.Stop()
+	})
+}
+
+func TestTimerManager(t *testing.T) {
+	sim := gomc.PrepareSimulation(
+		gomc.WithTreeStateManager(
+			func(node *TimerNode) int { return node.Fired },
+			func(s1, s2 int) bool { return s1 == s2 },
+		),
+		gomc.PrefixScheduler(),
+		gomc.NumConcurrent(1),
+	)
+	resp := sim.Run(
+		gomc.InitSingleNode([]int{0, 1},
+			func(id int, sp eventManager.SimulationParameters) *TimerNode {
+				return &TimerNode{id: id, timers: eventManager.NewTimerManager(sp)}
+			},
+		),
+		gomc.WithRequests(gomc.NewRequest(0, "Start"), gomc.NewRequest(1, "Start")),
+		gomc.WithPredicateChecker(
+			func(s checking.State[int]) bool {
+				return !s.IsTerminal || checking.ForAllNodes(func(fired int) bool { return fired == 1 }, s, false)
+			},
+		),
+	)
+	if ok, desc := resp.Response(); !ok {
+		t.Errorf("Expected exactly one timer to fire on each node. Got: %v", desc)
+	}
+}
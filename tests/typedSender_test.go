@@ -0,0 +1,58 @@
+package gomc_test
+
+import (
+	"gomc"
+	"gomc/checking"
+	"gomc/eventManager"
+	"testing"
+)
+
+type SumNode struct {
+	send func(int, string, int)
+	Sum  int
+}
+
+func (n *SumNode) Start() {
+	n.send(1, "Add", 1)
+	n.send(1, "Add", 2)
+}
+
+func (n *SumNode) Add(from int, val int) {
+	n.Sum += val
+}
+
+func TestTypedSender(t *testing.T) {
+	sim := gomc.PrepareSimulation(
+		gomc.WithTreeStateManager(
+			func(node *SumNode) int { return node.Sum },
+			func(s1, s2 int) bool { return s1 == s2 },
+		),
+		gomc.PrefixScheduler(),
+		gomc.NumConcurrent(1),
+	)
+	// The nodes are initialized off the test goroutine, so errors are checked after the run
+	var initErr error
+	resp := sim.Run(
+		gomc.InitSingleNode([]int{0, 1},
+			func(id int, sp eventManager.SimulationParameters) *SumNode {
+				sender := eventManager.NewTypedSender[SumNode, int](sp)
+				if err := sender.HandleMethod("Add"); err != nil && initErr == nil {
+					initErr = err
+				}
+				return &SumNode{send: sender.SendFunc(id)}
+			},
+		),
+		gomc.WithRequests(gomc.NewRequest(0, "Start")),
+		gomc.WithPredicateChecker(
+			checking.Eventually(func(s checking.State[int]) bool {
+				return s.LocalStates[1] == 3
+			}),
+		),
+	)
+	if initErr != nil {
+		t.Fatalf("Unable to register the handler: %v", initErr)
+	}
+	if ok, desc := resp.Response(); !ok {
+		t.Errorf("Expected both typed messages to be delivered. Got: %v", desc)
+	}
+}